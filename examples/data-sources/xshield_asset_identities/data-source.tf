@@ -0,0 +1,12 @@
+data "xshield_asset_identities" "cmdb" {
+  identifiers = ["web-01.example.com", "10.0.12.7", "db-01"]
+  concurrency = 4
+}
+
+output "web_asset_id" {
+  value = data.xshield_asset_identities.cmdb.asset_ids["web-01.example.com"]
+}
+
+output "not_in_xshield" {
+  value = data.xshield_asset_identities.cmdb.unresolved
+}
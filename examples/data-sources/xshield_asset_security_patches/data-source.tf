@@ -0,0 +1,3 @@
+data "xshield_asset_security_patches" "my_asset" {
+  asset_id = "12345678-1234-1234-1234-123456789012"
+}
@@ -0,0 +1,7 @@
+data "xshield_asset_tags" "web" {
+  asset_id = "12345678-1234-1234-1234-123456789012"
+}
+
+output "web_tags" {
+  value = { for key, values in data.xshield_asset_tags.web.values_by_key : key => join(",", values) }
+}
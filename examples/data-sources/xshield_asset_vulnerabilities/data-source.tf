@@ -0,0 +1,32 @@
+data "xshield_asset_vulnerabilities" "web" {
+  # Aggregate over every asset matching a criteria
+  criteria = "'app' in ('web') AND 'environment' in ('production')"
+
+  # Or over every asset matching an existing segment's criteria
+  # segment_id = "12345678-1234-1234-1234-123456789012"
+
+  # Or look at a single asset
+  # asset_id = "12345678-1234-1234-1234-123456789012"
+}
+
+# Refuse to promote the segment to enforced mode while critical CVEs are open
+resource "xshield_segment" "web" {
+  tag_based_policy_name             = "web"
+  criteria                          = "'app' in ('web') AND 'environment' in ('production')"
+  inbound_auto_sync_deployment_mode = var.web_deployment_mode
+
+  lifecycle {
+    precondition {
+      condition     = var.web_deployment_mode != "enforced" || data.xshield_asset_vulnerabilities.web.severity_counts.critical == 0
+      error_message = "Segment assets still have ${data.xshield_asset_vulnerabilities.web.severity_counts.critical} open critical CVEs."
+    }
+  }
+}
+
+# Continuously report open critical CVEs on every plan and apply
+check "no_critical_cves" {
+  assert {
+    condition     = data.xshield_asset_vulnerabilities.web.severity_counts.critical == 0
+    error_message = "Segment assets still have ${data.xshield_asset_vulnerabilities.web.severity_counts.critical} open critical CVEs."
+  }
+}
@@ -0,0 +1,3 @@
+data "xshield_asset_vulnerable_packages" "production" {
+  criteria = "'environment' in ('production')"
+}
@@ -0,0 +1,7 @@
+data "xshield_metadata_fields" "asset" {
+  scope = "asset"
+}
+
+output "core_tag_keys" {
+  value = [for field in data.xshield_metadata_fields.asset.fields : field.name if field.core_tag == true]
+}
@@ -0,0 +1,12 @@
+data "xshield_metrics" "web_by_location" {
+  criteria   = "role = 'web'"
+  group_by   = ["location"]
+  statistics = ["count"]
+
+  # Only look at the last day
+  lookback = "24h"
+}
+
+output "web_assets_per_location" {
+  value = { for row in data.xshield_metrics.web_by_location.rows : row.group["location"] => row.statistics["count"] }
+}
@@ -0,0 +1,10 @@
+data "xshield_named_network_hits" "legacy_dc" {
+  named_network_id = "12345678-1234-1234-1234-123456789012"
+
+  # Only look at traffic seen in the last 30 days
+  observed_within = "720h"
+}
+
+output "legacy_dc_still_used" {
+  value = data.xshield_named_network_hits.legacy_dc.hit_count > 0
+}
@@ -0,0 +1,12 @@
+data "xshield_network_map" "web_to_db" {
+  criteria             = "*"
+  source_criteria      = "role = 'web'"
+  destination_criteria = "role = 'db'"
+  group_by             = ["destinationport"]
+  statistics           = ["count"]
+  lookback             = "168h"
+}
+
+output "web_to_db_ports" {
+  value = [for row in data.xshield_network_map.web_to_db.rows : row.group["destinationport"]]
+}
@@ -0,0 +1,7 @@
+data "xshield_tags" "environments" {
+  names = ["environment"]
+}
+
+output "environments" {
+  value = data.xshield_tags.environments.values_by_key["environment"]
+}
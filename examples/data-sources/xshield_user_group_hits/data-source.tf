@@ -0,0 +1,4 @@
+data "xshield_user_group_hits" "contractors" {
+  user_group_id   = "12345678-1234-1234-1234-123456789012"
+  observed_within = "168h"
+}
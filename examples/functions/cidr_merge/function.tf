@@ -0,0 +1,13 @@
+resource "xshield_named_network" "datacenter" {
+  named_network_name = "datacenter"
+
+  # 10.0.0.0/23 and 192.168.10.0/24
+  ip_ranges = [
+    for cidr in provider::xshield::cidr_merge([
+      "10.0.0.0/24",
+      "10.0.1.0/24",
+      "10.0.0.12",
+      "192.168.10.0-192.168.10.255",
+    ]) : { ip_range = cidr }
+  ]
+}
@@ -0,0 +1,9 @@
+output "office_network" {
+  # "10.20.0.0/16"
+  value = provider::xshield::cidr_normalize("10.20.4.1/16")
+}
+
+output "gateway" {
+  # "10.20.0.1/32"
+  value = provider::xshield::cidr_normalize("10.20.0.1")
+}
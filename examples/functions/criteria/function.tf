@@ -0,0 +1,9 @@
+resource "xshield_segment" "web" {
+  tag_based_policy_name = "web-production"
+
+  # 'app' in ('web', 'api') AND 'environment' in ('production')
+  criteria = provider::xshield::criteria({
+    app         = ["web", "api"]
+    environment = ["production"]
+  })
+}
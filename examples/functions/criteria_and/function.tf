@@ -0,0 +1,14 @@
+resource "xshield_tag_rule" "production_web" {
+  rule_name = "production-web"
+
+  # ('app' in ('web') OR 'app' in ('api')) AND 'environment' in ('production')
+  rule_criteria = provider::xshield::criteria_and([
+    "'app' in ('web') OR 'app' in ('api')",
+    provider::xshield::criteria({ environment = ["production"] }),
+    var.extra_criteria, # skipped when empty
+  ])
+
+  on_match = {
+    role = "web"
+  }
+}
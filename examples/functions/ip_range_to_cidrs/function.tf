@@ -0,0 +1,4 @@
+output "dhcp_pool" {
+  # ["10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8/31"]
+  value = provider::xshield::ip_range_to_cidrs("10.0.0.1-10.0.0.9")
+}
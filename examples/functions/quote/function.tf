@@ -0,0 +1,4 @@
+data "xshield_asset_vulnerabilities" "owner" {
+  # owner = 'O''Brien'
+  criteria = "owner = ${provider::xshield::quote(var.owner)}"
+}
@@ -9,11 +9,22 @@ terraform {
 
 provider "xshield" {
   # Xshield connection config [Required]
+  # Each attribute may instead be set through its XSHIELD_* environment variable,
+  # for example XSHIELD_TENANCY_ID or XSHIELD_PRIVATE_KEY_PATH, or read from a
+  # profile of ~/.xshield/config:
+  # profile = "prod"
   tenancy_id = "xxxxxxxxxx"
   user_id = "xxxxxxxxxx"
   fingerprint = "xxxxxxxxxx"
   private_key_path = "/path/to/colortokens_api_key_tenant.pem"
+  # or pass the key content directly, for example from a secrets manager
+  # private_key = var.xshield_private_key
+  # private_key_passphrase = var.xshield_private_key_passphrase
+  # or obtain all signing material from a helper command printing JSON
+  # credential_process = "/usr/local/bin/xshield-credentials --tenant prod"
   server_url = "https://my-company.colortokens.com/"
+  # or pick the server of your region: iad, bom, fra or syd
+  # region = "fra"
 
   # HTTP request config [Optional]
   request_timeout = 60     
@@ -21,8 +32,21 @@ provider "xshield" {
   max_interval = 60000       
   max_elapsed_time = 3600000   
   exponent = 1.5
+  # Client-side limit shared by all resources, e.g. for large bulk applies
+  # requests_per_second = 10
+  # Bytes of each body logged with TF_LOG=DEBUG, credentials are always redacted
+  # log_body_limit = 4096
 
   # Proxy config [Optional]
   proxy_url       = "http://proxy-ip:port"
   proxy_creds     = "username:password"
+
+  # TLS config [Optional]
+  # Trust the CA of a TLS intercepting proxy in addition to the system roots
+  # ca_cert_file     = "/etc/ssl/certs/corporate-proxy-ca.pem"
+  # Present a client certificate for mutual TLS
+  # client_cert_file = "/path/to/client.crt"
+  # client_key_file  = "/path/to/client.key"
+  # Lab environments with self-signed certificates only
+  # insecure_skip_verify = true
 }
\ No newline at end of file
@@ -16,4 +16,9 @@ resource "xshield_segment" "my_segment" {
     }
   ]
   timeline = 10
+
+  # Bound the whole delete, including waiting for policy detachment to finish
+  timeouts {
+    delete = "20m"
+  }
 }
\ No newline at end of file
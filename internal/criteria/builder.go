@@ -0,0 +1,113 @@
+package criteria
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Quote returns value as a criteria string literal
+func Quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// Field returns the key of an asset or object field, such as assetName
+func Field(name string) Key {
+	return Key{Name: name}
+}
+
+// Tag returns the key of a tag, which is quoted, such as 'managedby'
+func Tag(name string) Key {
+	return Key{Name: name, Quoted: true}
+}
+
+// Eq returns the condition key = value
+func Eq(key Key, value string) *Condition {
+	return &Condition{Key: key, Op: OpEq, Values: []string{value}}
+}
+
+// NotEq returns the condition key != value
+func NotEq(key Key, value string) *Condition {
+	return &Condition{Key: key, Op: OpNotEq, Values: []string{value}}
+}
+
+// In returns the condition key in (values...)
+func In(key Key, values ...string) *Condition {
+	return &Condition{Key: key, Op: OpIn, Values: values}
+}
+
+// NotIn returns the condition key not in (values...)
+func NotIn(key Key, values ...string) *Condition {
+	return &Condition{Key: key, Op: OpNotIn, Values: values}
+}
+
+// AppendAnd returns the criteria text restricted by expr. The text is kept as written so that
+// it still matches what the API returns, except that a top level OR is parenthesized so that
+// expr applies to all of its terms.
+func AppendAnd(text string, expr Expr) string {
+	if strings.TrimSpace(text) == "" {
+		return expr.String()
+	}
+	if parsed, err := Parse(text); err == nil {
+		if or, ok := parsed.(Or); ok && len(or) > 1 {
+			text = "(" + text + ")"
+		}
+	}
+	if or, ok := expr.(Or); ok && len(or) > 1 {
+		return text + " AND (" + expr.String() + ")"
+	}
+	return text + " AND " + expr.String()
+}
+
+// TagsIn returns the criteria matching the assets having, for every key of tags, one of its
+// values: 'key' in ('a', 'b') AND ... in the order of the keys. No tags match every asset.
+func TagsIn(tags map[string][]string) Expr {
+	if len(tags) == 0 {
+		return All{}
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	terms := make(And, 0, len(keys))
+	for _, key := range keys {
+		terms = append(terms, In(Tag(key), tags[key]...))
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return terms
+}
+
+// JoinAnd returns the criteria texts joined with AND. Each text is kept as written, except that
+// a top level OR is parenthesized. Empty texts and "*" restrict nothing and are skipped, "*" is
+// returned when no text is left.
+func JoinAnd(texts ...string) (string, error) {
+	var terms []string
+	for i, text := range texts {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		parsed, err := Parse(text)
+		if err != nil {
+			return "", fmt.Errorf("criteria %d: %w", i, err)
+		}
+		switch e := parsed.(type) {
+		case All:
+			continue
+		case Or:
+			if len(e) > 1 {
+				text = "(" + text + ")"
+			}
+		}
+		terms = append(terms, text)
+	}
+	if len(terms) == 0 {
+		return All{}.String(), nil
+	}
+	return strings.Join(terms, " AND "), nil
+}
@@ -0,0 +1,142 @@
+package criteria
+
+import (
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	tests := map[string]string{
+		"":      "''",
+		"web":   "'web'",
+		"it's":  "'it''s'",
+		"''":    "''''''",
+		`"web"`: `'"web"'`,
+	}
+	for value, want := range tests {
+		if got := Quote(value); got != want {
+			t.Errorf("Quote(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestExprString(t *testing.T) {
+	tests := []struct {
+		expr Expr
+		want string
+	}{
+		{expr: All{}, want: "*"},
+		{expr: Eq(Field("assetName"), "web"), want: "assetName = 'web'"},
+		{expr: NotIn(Tag("app"), "web", "api"), want: "'app' not in ('web', 'api')"},
+		{
+			expr: And{Eq(Field("a"), "1"), Or{Eq(Field("b"), "2"), Eq(Field("c"), "3")}},
+			want: "a = '1' AND (b = '2' OR c = '3')",
+		},
+		{
+			expr: Or{And{Eq(Field("a"), "1"), Eq(Field("b"), "2")}, Eq(Field("c"), "3")},
+			want: "a = '1' AND b = '2' OR c = '3'",
+		},
+	}
+	for _, test := range tests {
+		if got := test.expr.String(); got != test.want {
+			t.Errorf("String() = %s, want %s", got, test.want)
+		}
+	}
+}
+
+func TestAppendAnd(t *testing.T) {
+	managed := In(Tag("managedby"), "colortokens")
+	tests := []struct {
+		name string
+		text string
+		expr Expr
+		want string
+	}{
+		{name: "empty", text: " ", expr: managed, want: "'managedby' in ('colortokens')"},
+		{name: "kept as written", text: "assetname='web'", expr: managed, want: "assetname='web' AND 'managedby' in ('colortokens')"},
+		{name: "top level or wrapped", text: "a = '1' OR b = '2'", expr: managed, want: "(a = '1' OR b = '2') AND 'managedby' in ('colortokens')"},
+		{name: "or expr wrapped", text: "a = '1'", expr: Or{Eq(Field("b"), "2"), Eq(Field("c"), "3")}, want: "a = '1' AND (b = '2' OR c = '3')"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := AppendAnd(test.text, test.expr); got != test.want {
+				t.Errorf("AppendAnd() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTagsIn(t *testing.T) {
+	tests := []struct {
+		name string
+		tags map[string][]string
+		want string
+	}{
+		{name: "none", tags: nil, want: "*"},
+		{name: "one", tags: map[string][]string{"app": {"web"}}, want: "'app' in ('web')"},
+		{
+			name: "sorted keys",
+			tags: map[string][]string{"env": {"prod"}, "app": {"web", "api"}},
+			want: "'app' in ('web', 'api') AND 'env' in ('prod')",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := TagsIn(test.tags).String(); got != test.want {
+				t.Errorf("TagsIn() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestJoinAnd(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  string
+	}{
+		{name: "nothing", texts: nil, want: "*"},
+		{name: "empty and all", texts: []string{"", " * ", "*"}, want: "*"},
+		{name: "kept as written", texts: []string{"assetname='web'", "'app' IN ('x')"}, want: "assetname='web' AND 'app' IN ('x')"},
+		{name: "top level or wrapped", texts: []string{"a = '1' OR b = '2'", "c = '3'"}, want: "(a = '1' OR b = '2') AND c = '3'"},
+		{name: "nested or kept", texts: []string{"(a = '1' OR b = '2') AND c = '3'", "d = '4'"}, want: "(a = '1' OR b = '2') AND c = '3' AND d = '4'"},
+		{name: "all skipped", texts: []string{"*", "c = '3'"}, want: "c = '3'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := JoinAnd(test.texts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("JoinAnd() = %s, want %s", got, test.want)
+			}
+			if _, err := Parse(got); err != nil {
+				t.Errorf("JoinAnd() = %s, which does not parse: %v", got, err)
+			}
+		})
+	}
+}
+
+func TestJoinAndKeepsOrPrecedence(t *testing.T) {
+	joined, err := JoinAnd("a = '1' OR b = '2'", "c = '3'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := Parse(joined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without the parentheses c = '3' would only restrict b = '2'
+	lookup := func(key string) []string {
+		return map[string][]string{"a": {"1"}}[key]
+	}
+	if Match(parsed, lookup) {
+		t.Errorf("%s matches an object without c = '3'", joined)
+	}
+}
+
+func TestJoinAndReportsInvalidCriteria(t *testing.T) {
+	if _, err := JoinAnd("a = '1'", "b = "); err == nil {
+		t.Fatal("joined invalid criteria")
+	}
+}
@@ -0,0 +1,141 @@
+// Package criteria builds, parses and prints the criteria expressions used by the xshield
+// API to select assets, such as
+//
+//	'app' in ('web', 'api') AND environment = 'production'
+//
+// Values are always quoted with single quotes, a quote inside a value is escaped by doubling
+// it. Keys are either bare names such as assetName or quoted like values, which is the
+// convention for tag keys such as 'managedby'. "*" matches every asset.
+package criteria
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Expr is a criteria expression: a *Condition, an And, an Or or All
+type Expr interface {
+	// String returns the expression in the criteria language
+	String() string
+	expr()
+}
+
+// Op is the operator of a condition
+type Op string
+
+const (
+	OpEq    Op = "="
+	OpNotEq Op = "!="
+	OpIn    Op = "in"
+	OpNotIn Op = "not in"
+)
+
+// Key is the key a condition tests
+type Key struct {
+	Name string
+	// Quoted keys are printed within single quotes. Names that are not valid bare names are
+	// always quoted.
+	Quoted bool
+}
+
+// bareKeyPattern matches the key names that can be printed without quotes
+var bareKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+func (k Key) String() string {
+	if k.Quoted || !bareKeyPattern.MatchString(k.Name) || isKeyword(k.Name) {
+		return Quote(k.Name)
+	}
+	return k.Name
+}
+
+// Condition compares the value of a key with one or more values
+type Condition struct {
+	Key    Key
+	Op     Op
+	Values []string
+	// Pos is the byte offset of the condition in the parsed criteria, 0 for built conditions
+	Pos int
+}
+
+func (*Condition) expr() {}
+
+func (c *Condition) String() string {
+	values := make([]string, len(c.Values))
+	for i, value := range c.Values {
+		values[i] = Quote(value)
+	}
+	if c.Op == OpIn || c.Op == OpNotIn {
+		return c.Key.String() + " " + string(c.Op) + " (" + strings.Join(values, ", ") + ")"
+	}
+	return c.Key.String() + " " + string(c.Op) + " " + strings.Join(values, ", ")
+}
+
+// And matches when all of its terms match
+type And []Expr
+
+func (And) expr() {}
+
+func (a And) String() string {
+	terms := make([]string, len(a))
+	for i, term := range a {
+		terms[i] = term.String()
+		if or, ok := term.(Or); ok && len(or) > 1 {
+			terms[i] = "(" + terms[i] + ")"
+		}
+	}
+	return strings.Join(terms, " AND ")
+}
+
+// Or matches when any of its terms matches
+type Or []Expr
+
+func (Or) expr() {}
+
+func (o Or) String() string {
+	terms := make([]string, len(o))
+	for i, term := range o {
+		terms[i] = term.String()
+	}
+	return strings.Join(terms, " OR ")
+}
+
+// All matches every asset
+type All struct{}
+
+func (All) expr() {}
+
+func (All) String() string {
+	return "*"
+}
+
+// Conditions returns the conditions of expr in the order they appear
+func Conditions(expr Expr) []*Condition {
+	var conditions []*Condition
+	var walk func(Expr)
+	walk = func(expr Expr) {
+		switch e := expr.(type) {
+		case *Condition:
+			conditions = append(conditions, e)
+		case And:
+			for _, term := range e {
+				walk(term)
+			}
+		case Or:
+			for _, term := range e {
+				walk(term)
+			}
+		}
+	}
+	walk(expr)
+	return conditions
+}
+
+// HasKey reports whether any condition of expr tests key, ignoring case
+func HasKey(expr Expr, key string) bool {
+	for _, condition := range Conditions(expr) {
+		if strings.EqualFold(condition.Key.Name, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package criteria
+
+import "strings"
+
+// Lookup returns the values of key for the object being evaluated, nil when it has none
+type Lookup func(key string) []string
+
+// Match reports whether the object described by lookup matches expr. Values are compared
+// ignoring case. A key without values matches != and not in, and nothing else.
+func Match(expr Expr, lookup Lookup) bool {
+	switch e := expr.(type) {
+	case All:
+		return true
+	case And:
+		for _, term := range e {
+			if !Match(term, lookup) {
+				return false
+			}
+		}
+		return true
+	case Or:
+		for _, term := range e {
+			if Match(term, lookup) {
+				return true
+			}
+		}
+		return false
+	case *Condition:
+		found := anyValueIn(lookup(e.Key.Name), e.Values)
+		if e.Op == OpNotEq || e.Op == OpNotIn {
+			return !found
+		}
+		return found
+	}
+	return false
+}
+
+// anyValueIn reports whether one of values is one of wanted, ignoring case
+func anyValueIn(values, wanted []string) bool {
+	for _, value := range values {
+		for _, w := range wanted {
+			if strings.EqualFold(value, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package criteria
+
+import (
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	values := map[string][]string{
+		"assetname": {"web-1"},
+		"app":       {"Web"},
+		"env":       {"prod", "eu"},
+	}
+	lookup := func(key string) []string {
+		return values[key]
+	}
+
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{text: "*", want: true},
+		{text: "assetname = 'web-1'", want: true},
+		{text: "assetname = 'web-2'", want: false},
+		{text: "'app' = 'WEB'", want: true},
+		{text: "'app' != 'web'", want: false},
+		{text: "'app' != 'api'", want: true},
+		{text: "'app' in ('api', 'web')", want: true},
+		{text: "'app' in ('api', 'db')", want: false},
+		{text: "'app' not in ('api', 'db')", want: true},
+		{text: "'app' not in ('api', 'web')", want: false},
+		{text: "'env' = 'eu'", want: true},
+		{text: "'env' != 'prod'", want: false},
+		{text: "'owner' = 'ops'", want: false},
+		{text: "'owner' in ('ops')", want: false},
+		{text: "'owner' != 'ops'", want: true},
+		{text: "'owner' not in ('ops')", want: true},
+		{text: "'app' = 'web' AND 'env' = 'prod'", want: true},
+		{text: "'app' = 'web' AND 'env' = 'dev'", want: false},
+		{text: "'app' = 'api' OR 'env' = 'prod'", want: true},
+		{text: "'app' = 'api' OR 'env' = 'dev'", want: false},
+		{text: "'app' = 'api' AND 'env' = 'dev' OR 'app' = 'web'", want: true},
+		{text: "'app' = 'api' AND ('env' = 'dev' OR 'app' = 'web')", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			expr, err := Parse(test.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Match(expr, lookup); got != test.want {
+				t.Errorf("Match() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMatchEmpty(t *testing.T) {
+	none := func(string) []string { return nil }
+	if !Match(And{}, none) {
+		t.Error("an empty And does not match")
+	}
+	if Match(Or{}, none) {
+		t.Error("an empty Or matches")
+	}
+}
@@ -0,0 +1,306 @@
+package criteria
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SyntaxError reports criteria that cannot be parsed
+type SyntaxError struct {
+	// Offset is the byte offset of the error in the criteria
+	Offset int
+	// Column is the 1-based column of the error, counted in characters
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at column %d: %s", e.Column, e.Msg)
+}
+
+// Column returns the 1-based column, counted in characters, of the byte offset in text
+func Column(text string, offset int) int {
+	return utf8.RuneCountInString(text[:offset]) + 1
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenName
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenEq
+	tokenNotEq
+	tokenStar
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of criteria"
+	case tokenString:
+		return fmt.Sprintf("string %s", t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// isKeyword reports whether name is a reserved word of the criteria language
+func isKeyword(name string) bool {
+	switch strings.ToLower(name) {
+	case "and", "or", "in", "not":
+		return true
+	}
+	return false
+}
+
+// Parse parses criteria text. AND binds tighter than OR, parentheses group terms.
+func Parse(text string) (Expr, error) {
+	p := &parser{text: text}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.errorf(p.tok.pos, "unexpected %s", p.tok.describe())
+	}
+	return expr, nil
+}
+
+type parser struct {
+	text string
+	pos  int
+	tok  token
+}
+
+func (p *parser) errorf(offset int, format string, args ...any) error {
+	return &SyntaxError{
+		Offset: offset,
+		Column: Column(p.text, offset),
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+// next reads the next token into p.tok
+func (p *parser) next() error {
+	for p.pos < len(p.text) && strings.ContainsRune(" \t\r\n", rune(p.text[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.text) {
+		p.tok = token{kind: tokenEOF, pos: start}
+		return nil
+	}
+
+	switch c := p.text[p.pos]; {
+	case c == '(':
+		p.pos++
+		p.tok = token{kind: tokenLParen, text: "(", pos: start}
+	case c == ')':
+		p.pos++
+		p.tok = token{kind: tokenRParen, text: ")", pos: start}
+	case c == ',':
+		p.pos++
+		p.tok = token{kind: tokenComma, text: ",", pos: start}
+	case c == '*':
+		p.pos++
+		p.tok = token{kind: tokenStar, text: "*", pos: start}
+	case c == '=':
+		p.pos++
+		p.tok = token{kind: tokenEq, text: "=", pos: start}
+	case c == '!' && strings.HasPrefix(p.text[p.pos:], "!="):
+		p.pos += 2
+		p.tok = token{kind: tokenNotEq, text: "!=", pos: start}
+	case c == '\'' || c == '"':
+		var value strings.Builder
+		p.pos++
+		for {
+			if p.pos >= len(p.text) {
+				return p.errorf(start, "unterminated string")
+			}
+			if p.text[p.pos] == c {
+				if p.pos+1 < len(p.text) && p.text[p.pos+1] == c {
+					value.WriteByte(c)
+					p.pos += 2
+					continue
+				}
+				p.pos++
+				break
+			}
+			value.WriteByte(p.text[p.pos])
+			p.pos++
+		}
+		p.tok = token{kind: tokenString, text: p.text[start:p.pos], value: value.String(), pos: start}
+	case isNameByte(c):
+		for p.pos < len(p.text) && isNameByte(p.text[p.pos]) {
+			p.pos++
+		}
+		p.tok = token{kind: tokenName, text: p.text[start:p.pos], value: p.text[start:p.pos], pos: start}
+	default:
+		r, _ := utf8.DecodeRuneInString(p.text[p.pos:])
+		return p.errorf(start, "unexpected character %q", r)
+	}
+	return nil
+}
+
+func isNameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-' || c == ':'
+}
+
+// isKeywordToken reports whether the current token is the keyword
+func (p *parser) isKeywordToken(keyword string) bool {
+	return p.tok.kind == tokenName && strings.EqualFold(p.tok.text, keyword)
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	term, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	terms := Or{term}
+	for p.isKeywordToken("or") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		term, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	if len(terms) == 1 {
+		return term, nil
+	}
+	return terms, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	term, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	terms := And{term}
+	for p.isKeywordToken("and") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		term, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	if len(terms) == 1 {
+		return term, nil
+	}
+	return terms, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	switch {
+	case p.tok.kind == tokenLParen:
+		open := p.tok.pos
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokenRParen {
+			if p.tok.kind == tokenEOF {
+				return nil, p.errorf(open, "unclosed parenthesis")
+			}
+			return nil, p.errorf(p.tok.pos, "expected \")\", found %s", p.tok.describe())
+		}
+		return expr, p.next()
+	case p.tok.kind == tokenStar:
+		return All{}, p.next()
+	case p.tok.kind == tokenString, p.tok.kind == tokenName && !isKeyword(p.tok.text):
+		return p.parseCondition()
+	}
+	return nil, p.errorf(p.tok.pos, "expected a condition, found %s", p.tok.describe())
+}
+
+func (p *parser) parseCondition() (Expr, error) {
+	condition := &Condition{
+		Key: Key{Name: p.tok.value, Quoted: p.tok.kind == tokenString},
+		Pos: p.tok.pos,
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.tok.kind == tokenEq, p.tok.kind == tokenNotEq:
+		condition.Op = OpEq
+		if p.tok.kind == tokenNotEq {
+			condition.Op = OpNotEq
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		condition.Values = []string{value}
+		return condition, nil
+	case p.isKeywordToken("in"), p.isKeywordToken("not"):
+		condition.Op = OpIn
+		if p.isKeywordToken("not") {
+			condition.Op = OpNotIn
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if !p.isKeywordToken("in") {
+				return nil, p.errorf(p.tok.pos, "expected \"in\" after \"not\", found %s", p.tok.describe())
+			}
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokenLParen {
+			return nil, p.errorf(p.tok.pos, "expected \"(\" after %q, found %s", string(condition.Op), p.tok.describe())
+		}
+		for {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			condition.Values = append(condition.Values, value)
+			if p.tok.kind == tokenRParen {
+				return condition, p.next()
+			}
+			if p.tok.kind != tokenComma {
+				return nil, p.errorf(p.tok.pos, "expected \",\" or \")\", found %s", p.tok.describe())
+			}
+		}
+	}
+	return nil, p.errorf(p.tok.pos, "expected \"=\", \"!=\", \"in\" or \"not in\" after key %s, found %s", condition.Key, p.tok.describe())
+}
+
+// parseValue reads a quoted value, or a bare one such as a number
+func (p *parser) parseValue() (string, error) {
+	if p.tok.kind != tokenString && (p.tok.kind != tokenName || isKeyword(p.tok.text)) {
+		return "", p.errorf(p.tok.pos, "expected a value, found %s", p.tok.describe())
+	}
+	value := p.tok.value
+	return value, p.next()
+}
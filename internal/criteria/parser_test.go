@@ -0,0 +1,145 @@
+package criteria
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// withoutPos returns expr with the positions of its conditions cleared, so that parsed and
+// built expressions can be compared
+func withoutPos(expr Expr) Expr {
+	switch e := expr.(type) {
+	case *Condition:
+		c := *e
+		c.Pos = 0
+		return &c
+	case And:
+		terms := make(And, len(e))
+		for i, term := range e {
+			terms[i] = withoutPos(term)
+		}
+		return terms
+	case Or:
+		terms := make(Or, len(e))
+		for i, term := range e {
+			terms[i] = withoutPos(term)
+		}
+		return terms
+	}
+	return expr
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want Expr
+	}{
+		{text: "*", want: All{}},
+		{text: "assetName = 'web'", want: Eq(Field("assetName"), "web")},
+		{text: "'app' != 'web'", want: NotEq(Tag("app"), "web")},
+		{text: "'app' in ('web', 'api')", want: In(Tag("app"), "web", "api")},
+		{text: "'app' NOT IN ('web')", want: NotIn(Tag("app"), "web")},
+		{text: `"app" = 'it''s'`, want: Eq(Tag("app"), "it's")},
+		{text: `'app' = "say ""hi"""`, want: Eq(Tag("app"), `say "hi"`)},
+		{text: "port = 443", want: Eq(Field("port"), "443")},
+		{
+			text: "a = '1' OR b = '2' AND c = '3'",
+			want: Or{Eq(Field("a"), "1"), And{Eq(Field("b"), "2"), Eq(Field("c"), "3")}},
+		},
+		{
+			text: "a = '1' AND b = '2' or c = '3'",
+			want: Or{And{Eq(Field("a"), "1"), Eq(Field("b"), "2")}, Eq(Field("c"), "3")},
+		},
+		{
+			text: "(a = '1' OR b = '2') AND c = '3'",
+			want: And{Or{Eq(Field("a"), "1"), Eq(Field("b"), "2")}, Eq(Field("c"), "3")},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			got, err := Parse(test.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(withoutPos(got), test.want) {
+				t.Errorf("Parse() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseConditionPos(t *testing.T) {
+	got, err := Parse("a = '1' AND 'b' in ('2')")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conditions := Conditions(got)
+	if len(conditions) != 2 || conditions[0].Pos != 0 || conditions[1].Pos != 12 {
+		t.Errorf("conditions at %v, want offsets 0 and 12", conditions)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		text   string
+		column int
+	}{
+		{text: "", column: 1},
+		{text: "a = 'web", column: 5},
+		{text: "(a = '1'", column: 1},
+		{text: "a = '1' AND", column: 12},
+		{text: "a ~ '1'", column: 3},
+		{text: "'app' in 'web'", column: 10},
+		{text: "'app' not ('web')", column: 11},
+		{text: "a = '1' b = '2'", column: 9},
+		{text: "é = '1'", column: 1},
+		{text: "'é' = '1' )", column: 11},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			_, err := Parse(test.text)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("err = %v, want a *SyntaxError", err)
+			}
+			if syntaxErr.Column != test.column {
+				t.Errorf("error at column %d, want %d: %v", syntaxErr.Column, test.column, err)
+			}
+		})
+	}
+}
+
+func TestQuotingRoundTrip(t *testing.T) {
+	for _, value := range []string{"", "web", "it's", "''", "a, b", "(x)", "AND", "ünïcode", `"double"`} {
+		t.Run(value, func(t *testing.T) {
+			built := And{In(Tag(value), value, "other"), Eq(Field("assetName"), value)}
+			parsed, err := Parse(built.String())
+			if err != nil {
+				t.Fatalf("Parse(%s): %v", built, err)
+			}
+			if !reflect.DeepEqual(withoutPos(parsed), Expr(built)) {
+				t.Errorf("Parse(%s) = %s, want the built expression back", built, parsed)
+			}
+		})
+	}
+}
+
+func TestKeyString(t *testing.T) {
+	tests := []struct {
+		key  Key
+		want string
+	}{
+		{key: Field("assetName"), want: "assetName"},
+		{key: Field("core.tags"), want: "core.tags"},
+		{key: Tag("app"), want: "'app'"},
+		{key: Field("has space"), want: "'has space'"},
+		{key: Field("in"), want: "'in'"},
+		{key: Field("1st"), want: "'1st'"},
+	}
+	for _, test := range tests {
+		if got := test.key.String(); got != test.want {
+			t.Errorf("%#v.String() = %s, want %s", test.key, got, test.want)
+		}
+	}
+}
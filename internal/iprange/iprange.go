@@ -0,0 +1,177 @@
+// Package iprange parses the IP ranges of named networks, written as a CIDR, a single address
+// or a "first-last" range of addresses, and converts them to and from minimal sets of CIDRs.
+package iprange
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// Range is the inclusive range of addresses from First to Last, both of the same family
+type Range struct {
+	First netip.Addr
+	Last  netip.Addr
+}
+
+// Parse parses an IP range written as a CIDR such as 10.0.0.0/8, a single address such as
+// 10.0.0.1 or a range of addresses such as 10.0.0.1-10.0.0.9. Host bits of a CIDR are ignored,
+// so 10.0.0.1/8 is the range of 10.0.0.0/8.
+func Parse(text string) (Range, error) {
+	text = strings.TrimSpace(text)
+	switch {
+	case strings.Contains(text, "/"):
+		prefix, err := netip.ParsePrefix(text)
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid CIDR %q: %w", text, err)
+		}
+		prefix = prefix.Masked()
+		return Range{First: prefix.Addr(), Last: lastAddr(prefix)}, nil
+	case strings.Contains(text, "-"):
+		firstText, lastText, _ := strings.Cut(text, "-")
+		first, err := parseAddr(strings.TrimSpace(firstText))
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid IP range %q: %w", text, err)
+		}
+		last, err := parseAddr(strings.TrimSpace(lastText))
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid IP range %q: %w", text, err)
+		}
+		if first.Is4() != last.Is4() {
+			return Range{}, fmt.Errorf("invalid IP range %q: addresses of different families", text)
+		}
+		if last.Less(first) {
+			return Range{}, fmt.Errorf("invalid IP range %q: %s is after %s", text, first, last)
+		}
+		return Range{First: first, Last: last}, nil
+	}
+	addr, err := parseAddr(text)
+	if err != nil {
+		return Range{}, fmt.Errorf("invalid IP address %q: %w", text, err)
+	}
+	return Range{First: addr, Last: addr}, nil
+}
+
+// parseAddr parses an address without zone
+func parseAddr(text string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(text)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if addr.Zone() != "" {
+		return netip.Addr{}, fmt.Errorf("address %q has a zone", text)
+	}
+	return addr, nil
+}
+
+// Normalize returns the canonical form of an IP range: the CIDR of the range when it is exactly
+// one, with its host bits cleared and /32 or /128 for a single address, "first-last" otherwise.
+// Two ranges covering the same addresses have the same canonical form.
+func Normalize(text string) (string, error) {
+	r, err := Parse(text)
+	if err != nil {
+		return "", err
+	}
+	return r.String(), nil
+}
+
+// String returns the canonical form of r, see Normalize
+func (r Range) String() string {
+	if prefixes := r.Prefixes(); len(prefixes) == 1 {
+		return prefixes[0].String()
+	}
+	return r.First.String() + "-" + r.Last.String()
+}
+
+// Prefixes returns the minimal list of CIDRs covering r, in order
+func (r Range) Prefixes() []netip.Prefix {
+	var prefixes []netip.Prefix
+	for first := r.First; ; {
+		// The largest CIDR starting at first that does not go past r.Last
+		var prefix netip.Prefix
+		for bits := 0; bits <= first.BitLen(); bits++ {
+			candidate := netip.PrefixFrom(first, bits)
+			if candidate.Masked().Addr() == first && !r.Last.Less(lastAddr(candidate)) {
+				prefix = candidate
+				break
+			}
+		}
+		prefixes = append(prefixes, prefix)
+
+		last := lastAddr(prefix)
+		if last == r.Last {
+			return prefixes
+		}
+		first = last.Next()
+	}
+}
+
+// Merge returns the minimal list of ranges covering the same addresses as ranges, merging the
+// ranges that overlap or are adjacent. IPv4 ranges come before IPv6 ones, each in order.
+func Merge(ranges []Range) []Range {
+	sorted := append([]Range(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].First.Less(sorted[j].First)
+	})
+
+	var merged []Range
+	for _, r := range sorted {
+		if n := len(merged); n > 0 {
+			current := &merged[n-1]
+			next := current.Last.Next()
+			if current.First.Is4() == r.First.Is4() && (!next.IsValid() || !next.Less(r.First)) {
+				if current.Last.Less(r.Last) {
+					current.Last = r.Last
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// MergeCIDRs parses texts and returns the minimal list of CIDRs covering the same addresses
+func MergeCIDRs(texts []string) ([]netip.Prefix, error) {
+	ranges := make([]Range, 0, len(texts))
+	for _, text := range texts {
+		r, err := Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+
+	var prefixes []netip.Prefix
+	for _, r := range Merge(ranges) {
+		prefixes = append(prefixes, r.Prefixes()...)
+	}
+	return prefixes, nil
+}
+
+// lastAddr returns the last address of prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr()
+	if addr.Is4() {
+		bytes := addr.As4()
+		setHostBits(bytes[:], prefix.Bits())
+		return netip.AddrFrom4(bytes)
+	}
+	bytes := addr.As16()
+	setHostBits(bytes[:], prefix.Bits())
+	return netip.AddrFrom16(bytes)
+}
+
+// setHostBits sets the bits of address after the first bits ones
+func setHostBits(address []byte, bits int) {
+	for i := range address {
+		switch {
+		case bits >= 8*(i+1):
+		case bits <= 8*i:
+			address[i] = 0xff
+		default:
+			address[i] |= 0xff >> (bits - 8*i)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package iprange
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+// mustParse returns the range of text, failing the test when it does not parse
+func mustParse(t *testing.T, text string) Range {
+	t.Helper()
+
+	r, err := Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text        string
+		first, last string
+	}{
+		{text: "10.0.0.0/24", first: "10.0.0.0", last: "10.0.0.255"},
+		{text: "10.0.0.5/24", first: "10.0.0.0", last: "10.0.0.255"},
+		{text: "10.0.0.5/32", first: "10.0.0.5", last: "10.0.0.5"},
+		{text: "0.0.0.0/0", first: "0.0.0.0", last: "255.255.255.255"},
+		{text: "10.0.0.5", first: "10.0.0.5", last: "10.0.0.5"},
+		{text: " 10.0.0.1 - 10.0.0.9 ", first: "10.0.0.1", last: "10.0.0.9"},
+		{text: "10.0.0.7-10.0.0.7", first: "10.0.0.7", last: "10.0.0.7"},
+		{text: "2001:db8::/32", first: "2001:db8::", last: "2001:db8:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{text: "2001:db8::1/128", first: "2001:db8::1", last: "2001:db8::1"},
+		{text: "2001:db8::1-2001:db8::ff", first: "2001:db8::1", last: "2001:db8::ff"},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			got := mustParse(t, test.text)
+			want := Range{First: netip.MustParseAddr(test.first), Last: netip.MustParseAddr(test.last)}
+			if got != want {
+				t.Errorf("Parse() = %s-%s, want %s-%s", got.First, got.Last, want.First, want.Last)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"10.0.0.0/33",
+		"10.0.0",
+		"10.0.0.9-10.0.0.1",
+		"10.0.0.1-2001:db8::1",
+		"10.0.0.1-",
+		"fe80::1%eth0",
+		"fe80::1%eth0-fe80::2",
+	} {
+		if r, err := Parse(text); err == nil {
+			t.Errorf("Parse(%q) = %s, want an error", text, r)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.5/24":             "10.0.0.0/24",
+		"10.0.0.5":                "10.0.0.5/32",
+		"10.0.0.5/32":             "10.0.0.5/32",
+		"10.0.0.0-10.0.0.255":     "10.0.0.0/24",
+		"10.0.0.0-10.0.1.255":     "10.0.0.0/23",
+		"10.0.0.1-10.0.0.9":       "10.0.0.1-10.0.0.9",
+		"10.0.0.0-10.0.0.254":     "10.0.0.0-10.0.0.254",
+		"10.0.0.128-10.0.1.127":   "10.0.0.128-10.0.1.127",
+		"2001:db8::1":             "2001:db8::1/128",
+		"2001:db8::5/64":          "2001:db8::/64",
+		"2001:db8::-2001:db8::ff": "2001:db8::/120",
+		"2001:db8::1-2001:db8::2": "2001:db8::1-2001:db8::2",
+	}
+	for text, want := range tests {
+		got, err := Normalize(text)
+		if err != nil {
+			t.Fatalf("Normalize(%q): %v", text, err)
+		}
+		if got != want {
+			t.Errorf("Normalize(%q) = %s, want %s", text, got, want)
+		}
+	}
+}
+
+func TestPrefixes(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "10.0.0.0/24", want: []string{"10.0.0.0/24"}},
+		{text: "10.0.0.5", want: []string{"10.0.0.5/32"}},
+		{text: "10.0.0.0-10.0.0.254", want: []string{"10.0.0.0/25", "10.0.0.128/26", "10.0.0.192/27", "10.0.0.224/28", "10.0.0.240/29", "10.0.0.248/30", "10.0.0.252/31", "10.0.0.254/32"}},
+		{text: "10.0.0.1-10.0.0.6", want: []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"}},
+		{text: "10.0.0.128-10.0.1.127", want: []string{"10.0.0.128/25", "10.0.1.0/25"}},
+		{text: "255.255.255.254-255.255.255.255", want: []string{"255.255.255.254/31"}},
+		{text: "2001:db8::1-2001:db8::3", want: []string{"2001:db8::1/128", "2001:db8::2/127"}},
+		{text: "::/0", want: []string{"::/0"}},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			var got []string
+			for _, prefix := range mustParse(t, test.text).Prefixes() {
+				got = append(got, prefix.String())
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("Prefixes() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []string
+		want   []string
+	}{
+		{name: "none", ranges: nil, want: nil},
+		{name: "disjoint", ranges: []string{"10.0.2.0/24", "10.0.0.0/24"}, want: []string{"10.0.0.0/24", "10.0.2.0/24"}},
+		{name: "adjacent", ranges: []string{"10.0.1.0/24", "10.0.0.0/24"}, want: []string{"10.0.0.0/23"}},
+		{name: "overlapping", ranges: []string{"10.0.0.0-10.0.0.200", "10.0.0.100-10.0.1.255"}, want: []string{"10.0.0.0/23"}},
+		{name: "contained", ranges: []string{"10.0.0.0/16", "10.0.5.5"}, want: []string{"10.0.0.0/16"}},
+		{name: "adjacent addresses", ranges: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, want: []string{"10.0.0.1-10.0.0.3"}},
+		{name: "end of the address space", ranges: []string{"255.255.255.0/24", "255.255.255.255"}, want: []string{"255.255.255.0/24"}},
+		{name: "ipv6", ranges: []string{"2001:db8::100-2001:db8::1ff", "2001:db8::/120"}, want: []string{"2001:db8::/119"}},
+		{
+			name:   "mixed families",
+			ranges: []string{"2001:db8::/64", "10.0.0.0/24", "::/128", "255.255.255.255"},
+			want:   []string{"10.0.0.0/24", "255.255.255.255/32", "::/128", "2001:db8::/64"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var ranges []Range
+			for _, text := range test.ranges {
+				ranges = append(ranges, mustParse(t, text))
+			}
+			var got []string
+			for _, r := range Merge(ranges) {
+				got = append(got, r.String())
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("Merge() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMergeCIDRs(t *testing.T) {
+	got, err := MergeCIDRs([]string{"10.0.0.5/24", "10.0.1.0-10.0.1.255", "10.0.2.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/23"), netip.MustParsePrefix("10.0.2.1/32")}
+	if !slices.Equal(got, want) {
+		t.Errorf("MergeCIDRs() = %v, want %v", got, want)
+	}
+
+	if _, err := MergeCIDRs([]string{"10.0.0.0/24", "nope"}); err == nil {
+		t.Error("MergeCIDRs() accepted an invalid range")
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Keys of ErrorResponse.Details whose value names the field the error is about
+var errorDetailFieldKeys = []string{"field", "fieldName", "attribute", "property", "path", "param"}
+
+// addAPIError reports a failed API call. err is the error returned by the SDK, if any, and
+// res the raw response, if one was received. The ErrorResponse returned by the API becomes
+// the detail of the diagnostic, which points at the attribute of model named by its
+// details. The raw request and response are only written to the debug log.
+func addAPIError(ctx context.Context, diags *diag.Diagnostics, summary string, err error, res *http.Response, model any) {
+	detail, field := apiErrorDetail(ctx, err, res)
+	if attributePath, ok := attributePathForField(model, field); ok {
+		diags.AddAttributeError(attributePath, summary, detail)
+		return
+	}
+	diags.AddError(summary, detail)
+}
+
+// apiErrorMessage returns the error message of a failed API call for wrapping in an error
+func apiErrorMessage(ctx context.Context, res *http.Response) string {
+	detail, _ := apiErrorDetail(ctx, nil, res)
+	return detail
+}
+
+// apiErrorDetail describes a failed API call and returns the field it is about, if the API
+// named one
+func apiErrorDetail(ctx context.Context, err error, res *http.Response) (string, string) {
+	var body []byte
+	var apiErr *sdkerrors.APIError
+	var sdkErr *sdkerrors.SDKError
+	if errors.As(err, &apiErr) {
+		body = []byte(apiErr.Body)
+		if res == nil {
+			res = apiErr.RawResponse
+		}
+	} else if errors.As(err, &sdkErr) {
+		body = []byte(sdkErr.Body)
+		if res == nil {
+			res = sdkErr.RawResponse
+		}
+	} else if res != nil && res.Body != nil {
+		body, _ = io.ReadAll(res.Body)
+		res.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	var lines []string
+	var field string
+	var errorResponse shared.ErrorResponse
+	if json.Unmarshal(body, &errorResponse) == nil && (errorResponse.Message != nil || len(errorResponse.Details) > 0) {
+		if errorResponse.Message != nil {
+			lines = append(lines, *errorResponse.Message)
+		}
+		lines = append(lines, formatErrorDetails(errorResponse.Details)...)
+		field = errorDetailField(errorResponse.Details)
+	} else if sdkErr != nil {
+		lines = append(lines, fmt.Sprintf("%s: Status %d", sdkErr.Message, sdkErr.StatusCode))
+	} else if apiErr == nil && err != nil {
+		lines = append(lines, err.Error())
+	}
+
+	if res != nil {
+		if len(lines) == 0 && res.Request != nil {
+			lines = append(lines, fmt.Sprintf("%s %s returned %s.", res.Request.Method, res.Request.URL.Path, res.Status))
+		} else if len(lines) == 0 {
+			lines = append(lines, fmt.Sprintf("The API returned %s.", res.Status))
+		}
+		if apiErr != nil && apiErr.RequestID != "" {
+			lines = append(lines, fmt.Sprintf("Request ID: %s", apiErr.RequestID))
+		}
+		tflog.Debug(ctx, "API request and response", map[string]interface{}{
+			"http": debugResponse(res),
+		})
+		lines = append(lines, "", "Set TF_LOG=DEBUG to log the full request and response.")
+	}
+
+	return strings.Join(lines, "\n"), field
+}
+
+// formatErrorDetails lists the details of an ErrorResponse in a stable order
+func formatErrorDetails(details map[string]any) []string {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := details[key]
+		if s, ok := value.(string); ok {
+			lines = append(lines, fmt.Sprintf("  %s: %s", key, s))
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			encoded = []byte(fmt.Sprint(value))
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", key, encoded))
+	}
+	return lines
+}
+
+// errorDetailField returns the API field named by the details of an ErrorResponse, either
+// as the value of a key such as "field" or as the only key of the details
+func errorDetailField(details map[string]any) string {
+	for _, key := range errorDetailFieldKeys {
+		if field, ok := details[key].(string); ok && field != "" {
+			return field
+		}
+	}
+	if len(details) == 1 {
+		for key := range details {
+			return key
+		}
+	}
+	return ""
+}
+
+// attributePathForField maps a field of the API, such as "ruleCriteria" or
+// "templates[0].templateName", to the top level attribute of model with the same name
+func attributePathForField(model any, field string) (path.Path, bool) {
+	if model == nil || field == "" {
+		return path.Empty(), false
+	}
+	field, _, _ = strings.Cut(field, ".")
+	field, _, _ = strings.Cut(field, "[")
+	field = normalizeFieldName(field)
+
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return path.Empty(), false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		attribute := t.Field(i).Tag.Get("tfsdk")
+		if attribute == "" || attribute == "-" {
+			continue
+		}
+		if normalizeFieldName(attribute) == field || normalizeFieldName(t.Field(i).Name) == field {
+			return path.Root(attribute), true
+		}
+	}
+	return path.Empty(), false
+}
+
+// normalizeFieldName lets camelCase API fields match snake_case attributes
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
@@ -4,10 +4,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -702,27 +704,25 @@ func (r *AssetDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		AssetID: assetID,
 	}
 	res, err := r.client.Assets.GetAsset(ctx, request)
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
 		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
 		return
 	}
-	if res.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.AssetDetails != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedAssetDetails(res.AssetDetails)
@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultAssetIdentityConcurrency bounds the number of asset lookups in flight when the
+// concurrency attribute is not set
+const defaultAssetIdentityConcurrency = 8
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetIdentitiesDataSource{}
+var _ datasource.DataSourceWithConfigure = &AssetIdentitiesDataSource{}
+
+func NewAssetIdentitiesDataSource() datasource.DataSource {
+	return &AssetIdentitiesDataSource{}
+}
+
+// AssetIdentitiesDataSource is the data source implementation.
+type AssetIdentitiesDataSource struct {
+	client *sdk.Xshield
+}
+
+// AssetIdentitiesDataSourceModel describes the data model.
+type AssetIdentitiesDataSourceModel struct {
+	Ambiguous   []types.String          `tfsdk:"ambiguous"`
+	AssetIds    map[string]types.String `tfsdk:"asset_ids"`
+	Concurrency types.Int64             `tfsdk:"concurrency"`
+	Identifiers []types.String          `tfsdk:"identifiers"`
+	Results     []tfTypes.AssetIdentity `tfsdk:"results"`
+	Unresolved  []types.String          `tfsdk:"unresolved"`
+}
+
+// Metadata returns the data source type name.
+func (r *AssetIdentitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_identities"
+}
+
+// Schema defines the schema for the data source.
+func (r *AssetIdentitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "AssetIdentities DataSource",
+
+		Attributes: map[string]schema.Attribute{
+			"ambiguous": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: `Identifiers that matched more than one asset`,
+			},
+			"asset_ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: `Asset ID of every identifier that resolved to exactly one asset, by identifier`,
+			},
+			"concurrency": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 32),
+				},
+				Description: `Maximum number of lookups in flight. Default: 8`,
+			},
+			"identifiers": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: `Hostnames, IP addresses or deterministic IDs to resolve`,
+			},
+			"results": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"asset_id": schema.StringAttribute{
+							Computed:    true,
+							Description: `Null unless the identifier matched exactly one asset`,
+						},
+						"asset_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"identifier": schema.StringAttribute{
+							Computed: true,
+						},
+						"match_count": schema.Int64Attribute{
+							Computed:    true,
+							Description: `Number of distinct assets the identifier matched`,
+						},
+						"matched_by": schema.StringAttribute{
+							Computed:    true,
+							Description: `How the identifier was matched: "identity", or the criteria key that matched. Null when unresolved.`,
+						},
+					},
+				},
+				Description: `One result per identifier, in input order`,
+			},
+			"unresolved": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: `Identifiers that did not match any asset`,
+			},
+		},
+	}
+}
+
+func (r *AssetIdentitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.Xshield)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AssetIdentitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *AssetIdentitiesDataSourceModel
+	var item types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &item)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(item.As(ctx, &data, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identity, err := getAssetIdentity(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving asset identity", err.Error())
+		return
+	}
+
+	concurrency := defaultAssetIdentityConcurrency
+	if !data.Concurrency.IsNull() {
+		concurrency = int(data.Concurrency.ValueInt64())
+	}
+
+	identifiers := make([]string, 0, len(data.Identifiers))
+	for _, identifier := range data.Identifiers {
+		identifiers = append(identifiers, identifier.ValueString())
+	}
+
+	resolutions, err := resolveAssetIdentities(ctx, r.client, identity, identifiers, concurrency)
+	if err != nil {
+		resp.Diagnostics.AddError("Error resolving asset identities", err.Error())
+		return
+	}
+
+	data.RefreshFromAssetResolutions(resolutions)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// assetResolution is the outcome of resolving one identifier
+type assetResolution struct {
+	Identifier string
+	MatchedBy  string
+	Assets     []selectedAsset
+}
+
+// getAssetIdentity returns the identity the API associates with the caller, nil when it
+// has none. Callers that are not agents are usually refused an identity, which is not an
+// error here since every identifier can still be resolved through criteria lookups.
+func getAssetIdentity(ctx context.Context, client *sdk.Xshield) (*shared.AssetIDInfo, error) {
+	res, err := client.Assets.GetAssetIdentity(ctx)
+	var unauthorized *sdkerrors.UnauthorizedError
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &unauthorized) || errors.As(err, &notFound) {
+		tflog.Debug(ctx, "No asset identity for the caller", map[string]interface{}{"error": err.Error()})
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.AssetIDInfo, nil
+}
+
+// resolveAssetIdentities resolves every identifier, running at most concurrency criteria
+// lookups at once. Identifiers matching the deterministic or agent ID of identity resolve
+// without a lookup.
+func resolveAssetIdentities(ctx context.Context, client *sdk.Xshield, identity *shared.AssetIDInfo, identifiers []string, concurrency int) ([]assetResolution, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resolutions := make([]assetResolution, len(identifiers))
+	errs := make([]error, len(identifiers))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, identifier := range identifiers {
+		resolutions[i].Identifier = identifier
+		if identity != nil && identity.AssetID != nil && identifier != "" &&
+			(identifier == identity.DeterministicID || (identity.AgentID != nil && identifier == *identity.AgentID)) {
+			resolutions[i].MatchedBy = "identity"
+			resolutions[i].Assets = []selectedAsset{{ID: *identity.AssetID}}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, identifier string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			for _, key := range assetIdentityLookupKeys(identifier) {
+				assets, err := listAssetsMatchingCriteria(ctx, client, criteria.Eq(criteria.Field(key), identifier).String())
+				if err != nil {
+					errs[i] = fmt.Errorf("looking up %q by %s: %w", identifier, key, err)
+					cancel()
+					return
+				}
+				if assets = distinctAssets(assets); len(assets) > 0 {
+					resolutions[i].MatchedBy = key
+					resolutions[i].Assets = assets
+					return
+				}
+			}
+		}(i, identifier)
+	}
+	wg.Wait()
+
+	// Report the error that caused the cancellation rather than the cancellations it caused
+	var canceled error
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		if err != nil && canceled == nil {
+			canceled = err
+		}
+	}
+	if canceled != nil {
+		return nil, canceled
+	}
+
+	return resolutions, nil
+}
+
+// assetIdentityLookupKeys returns the criteria keys tried in order to resolve identifier
+func assetIdentityLookupKeys(identifier string) []string {
+	if identifier == "" {
+		return nil
+	}
+	if net.ParseIP(identifier) != nil {
+		return []string{"ipaddresses"}
+	}
+	return []string{"assetName", "hostName", "deterministicId"}
+}
+
+// distinctAssets removes repeated asset IDs from assets
+func distinctAssets(assets []selectedAsset) []selectedAsset {
+	seen := map[string]bool{}
+	var out []selectedAsset
+	for _, asset := range assets {
+		if seen[asset.ID] {
+			continue
+		}
+		seen[asset.ID] = true
+		out = append(out, asset)
+	}
+	return out
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (r *AssetIdentitiesDataSourceModel) RefreshFromAssetResolutions(resolutions []assetResolution) {
+	r.Ambiguous = []types.String{}
+	r.AssetIds = map[string]types.String{}
+	r.Results = []tfTypes.AssetIdentity{}
+	r.Unresolved = []types.String{}
+
+	for _, resolution := range resolutions {
+		var results1 tfTypes.AssetIdentity
+		results1.Identifier = types.StringValue(resolution.Identifier)
+		results1.MatchCount = types.Int64Value(int64(len(resolution.Assets)))
+		results1.AssetID = types.StringNull()
+		results1.AssetName = types.StringNull()
+		results1.MatchedBy = types.StringNull()
+
+		switch len(resolution.Assets) {
+		case 0:
+			r.Unresolved = append(r.Unresolved, types.StringValue(resolution.Identifier))
+		case 1:
+			results1.AssetID = types.StringValue(resolution.Assets[0].ID)
+			results1.AssetName = resolution.Assets[0].assetNameValue()
+			results1.MatchedBy = types.StringValue(resolution.MatchedBy)
+			r.AssetIds[resolution.Identifier] = results1.AssetID
+		default:
+			results1.MatchedBy = types.StringValue(resolution.MatchedBy)
+			r.Ambiguous = append(r.Ambiguous, types.StringValue(resolution.Identifier))
+		}
+
+		r.Results = append(r.Results, results1)
+	}
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+)
+
+func TestGetAssetIdentity(t *testing.T) {
+	identity := shared.AssetIDInfo{AssetID: ptr("asset-1"), DeterministicID: "det-1"}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, identity)
+	}))
+	got, err := getAssetIdentity(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.DeterministicID != "det-1" || got.AssetID == nil || *got.AssetID != "asset-1" {
+		t.Errorf("getAssetIdentity() = %+v, want %+v", got, identity)
+	}
+}
+
+func TestGetAssetIdentityNone(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, status, map[string]string{"message": "no identity"})
+			}))
+			got, err := getAssetIdentity(context.Background(), client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != nil {
+				t.Errorf("getAssetIdentity() = %+v, want none", got)
+			}
+		})
+	}
+}
+
+func TestGetAssetIdentityError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusBadRequest, map[string]string{"message": "bad request"})
+	}))
+	_, err := getAssetIdentity(context.Background(), client)
+	var validation *sdkerrors.ValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("err = %v, want a validation error", err)
+	}
+}
@@ -4,14 +4,18 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
 	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -148,6 +152,7 @@ type AssetResourceModel struct {
 	VendorInfo                            types.String                            `tfsdk:"vendor_info"`
 	VirtualizationSystem                  types.String                            `tfsdk:"virtualization_system"`
 	Vulnerabilities                       types.Int64                             `tfsdk:"vulnerabilities"`
+	Timeouts                              timeouts.Value                          `tfsdk:"timeouts"`
 }
 
 func (r *AssetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -857,6 +862,9 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -866,16 +874,16 @@ func (r *AssetResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	providerData, ok := req.ProviderData.(*sdk.Xshield)
+	providerData, ok := req.ProviderData.(*resourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = providerData
+	r.client = providerData.client
 }
 
 // ModifyPlan prevents asset creation through Terraform
@@ -906,6 +914,9 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.State, timeoutRead, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *AssetResourceModel
 	var item types.Object
 
@@ -929,27 +940,25 @@ func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		AssetID: assetID,
 	}
 	res, err := r.client.Assets.GetAsset(ctx, request)
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
 		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
 		return
 	}
-	if res.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.AssetDetails != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedAssetDetails(res.AssetDetails)
@@ -959,6 +968,9 @@ func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.Plan, timeoutUpdate, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *AssetResourceModel
 	var plan types.Object
 
@@ -981,10 +993,7 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 	res, err := r.client.Assets.UpdateAsset(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
@@ -992,11 +1001,11 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.AssetDetails != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedAssetDetails(res.AssetDetails)
@@ -1007,6 +1016,9 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *AssetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.State, timeoutDelete, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *AssetResourceModel
 	var item types.Object
 
@@ -1037,7 +1049,7 @@ func (r *AssetResource) ImportState(ctx context.Context, req resource.ImportStat
 
 	// If not a UUID, assume it's a name and look up the asset
 	// Create a search criteria that filters by the asset name
-	searchCriteria := fmt.Sprintf("assetName = '%s'", req.ID)
+	searchCriteria := criteria.Eq(criteria.Field("assetName"), req.ID).String()
 	listReq := operations.ListAssetsRequest{
 		SearchInput: shared.SearchInput{
 			Criteria: searchCriteria,
@@ -1050,10 +1062,11 @@ func (r *AssetResource) ImportState(ctx context.Context, req resource.ImportStat
 		"search_criteria": listReq.SearchInput.Criteria,
 	})
 
-	// Try to get the assets - explicitly request JSON format
+	// Search every page of the assets - explicitly request JSON format
 	jsonAccept := operations.WithAcceptHeaderOverride(operations.AcceptHeaderEnumApplicationJson)
-	assets, err := r.client.Assets.ListAssets(ctx, listReq, jsonAccept)
-
+	asset, found, err := r.client.Assets.ListAssetsPager(listReq, sdk.DefaultPageSize, jsonAccept).Find(ctx, func(asset shared.ExtendedAssetSummary) bool {
+		return asset.AssetName == req.ID && asset.AssetID != nil
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving assets",
@@ -1062,27 +1075,13 @@ func (r *AssetResource) ImportState(ctx context.Context, req resource.ImportStat
 		return
 	}
 
-	// Process the JSON response
-	if assets.AssetSearchResults != nil && len(assets.AssetSearchResults.Items) > 0 {
-		// Find the asset with the matching name
-		var foundID string
-		for _, asset := range assets.AssetSearchResults.Items {
-			if asset.AssetName == req.ID {
-				if asset.AssetID != nil {
-					foundID = *asset.AssetID
-					break
-				}
-			}
-		}
-
-		if foundID != "" {
-			tflog.Info(ctx, "Found asset", map[string]interface{}{
-				"id":   foundID,
-				"name": req.ID,
-			})
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), foundID)...)
-			return
-		}
+	if found {
+		tflog.Info(ctx, "Found asset", map[string]interface{}{
+			"id":   *asset.AssetID,
+			"name": req.ID,
+		})
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), *asset.AssetID)...)
+		return
 	}
 
 	resp.Diagnostics.AddError(
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetSecurityPatchesDataSource{}
+var _ datasource.DataSourceWithConfigure = &AssetSecurityPatchesDataSource{}
+
+func NewAssetSecurityPatchesDataSource() datasource.DataSource {
+	return &AssetSecurityPatchesDataSource{}
+}
+
+// AssetSecurityPatchesDataSource is the data source implementation.
+type AssetSecurityPatchesDataSource struct {
+	client *sdk.Xshield
+}
+
+// AssetSecurityPatchesDataSourceModel describes the data model.
+type AssetSecurityPatchesDataSourceModel struct {
+	AssetCount           types.Int64                  `tfsdk:"asset_count"`
+	AssetID              types.String                 `tfsdk:"asset_id"`
+	AssetsMissingPatches types.Int64                  `tfsdk:"assets_missing_patches"`
+	Criteria             types.String                 `tfsdk:"criteria"`
+	PatchCount           types.Int64                  `tfsdk:"patch_count"`
+	Patches              []tfTypes.AssetSecurityPatch `tfsdk:"patches"`
+	SegmentID            types.String                 `tfsdk:"segment_id"`
+}
+
+// Metadata returns the data source type name.
+func (r *AssetSecurityPatchesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_security_patches"
+}
+
+// Schema defines the schema for the data source.
+func (r *AssetSecurityPatchesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := assetSelectionSchemaAttributes()
+	attributes["assets_missing_patches"] = schema.Int64Attribute{
+		Computed:    true,
+		Description: `Number of selected assets with at least one missing security patch`,
+	}
+	attributes["patch_count"] = schema.Int64Attribute{
+		Computed:    true,
+		Description: `Number of missing security patches found over all selected assets`,
+	}
+	attributes["patches"] = schema.ListNestedAttribute{
+		Computed: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"asset_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"asset_name": schema.StringAttribute{
+					Computed: true,
+				},
+				"new_release": schema.StringAttribute{
+					Computed:    true,
+					Description: `Release that contains the patch`,
+				},
+				"new_version": schema.StringAttribute{
+					Computed:    true,
+					Description: `Version that contains the patch`,
+				},
+				"package_name": schema.StringAttribute{
+					Computed: true,
+				},
+				"release": schema.StringAttribute{
+					Computed:    true,
+					Description: `Currently installed release`,
+				},
+				"version": schema.StringAttribute{
+					Computed:    true,
+					Description: `Currently installed version`,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "AssetSecurityPatches DataSource",
+
+		Attributes: attributes,
+	}
+}
+
+func (r *AssetSecurityPatchesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.Xshield)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AssetSecurityPatchesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *AssetSecurityPatchesDataSourceModel
+	var item types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &item)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(item.As(ctx, &data, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assets, diags := resolveAssetSelection(ctx, r.client, data.AssetID, data.Criteria, data.SegmentID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	patchesPerAsset, err := collectPerAsset(ctx, assets, func(ctx context.Context, asset selectedAsset) ([]shared.DevAzureComColortokensAssetPkgSecurityPatch, error) {
+		request := operations.ListSecurityPatchesRequest{
+			AssetID: asset.ID,
+		}
+		return r.client.Assets.ListSecurityPatchesPager(request, assetListPageSize).Collect(ctx)
+	})
+	if err != nil {
+		addAssetFetchError(ctx, &resp.Diagnostics, "Error listing security patches", err, data)
+		return
+	}
+
+	data.Patches = []tfTypes.AssetSecurityPatch{}
+	assetsMissingPatches := int64(0)
+	for i, asset := range assets {
+		if len(patchesPerAsset[i]) > 0 {
+			assetsMissingPatches++
+		}
+		data.AppendFromSharedSecurityPatches(asset, patchesPerAsset[i])
+	}
+	data.AssetCount = types.Int64Value(int64(len(assets)))
+	data.AssetsMissingPatches = types.Int64Value(assetsMissingPatches)
+	data.PatchCount = types.Int64Value(int64(len(data.Patches)))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,21 @@
+package provider
+
+import (
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (r *AssetSecurityPatchesDataSourceModel) AppendFromSharedSecurityPatches(asset selectedAsset, patches []shared.DevAzureComColortokensAssetPkgSecurityPatch) {
+	for _, patchesItem := range patches {
+		var patch tfTypes.AssetSecurityPatch
+		patch.AssetID = types.StringValue(asset.ID)
+		patch.AssetName = asset.assetNameValue()
+		patch.NewRelease = types.StringPointerValue(patchesItem.NewRelease)
+		patch.NewVersion = types.StringPointerValue(patchesItem.NewVersion)
+		patch.PackageName = types.StringPointerValue(patchesItem.PackageName)
+		patch.Release = types.StringPointerValue(patchesItem.Release)
+		patch.Version = types.StringPointerValue(patchesItem.Version)
+		r.Patches = append(r.Patches, patch)
+	}
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/errgroup"
+)
+
+// assetListPageSize is the page size used when walking ListBasicAssets results
+const assetListPageSize int64 = 100
+
+// assetFetchConcurrency is the number of assets whose results are fetched at the same time
+// when aggregating over an asset selection
+const assetFetchConcurrency = 4
+
+// selectedAsset identifies one asset picked by an asset selection
+type selectedAsset struct {
+	ID   string
+	Name string
+}
+
+// assetSelectionSchemaAttributes returns the attributes shared by data sources that operate
+// either on a single asset or on every asset matched by a criteria or a segment
+func assetSelectionSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"asset_id": schema.StringAttribute{
+			Optional:    true,
+			Description: `ID of a single asset. Exactly one of asset_id, criteria or segment_id must be provided.`,
+		},
+		"criteria": schema.StringAttribute{
+			Optional:    true,
+			Description: `Asset criteria. Results are aggregated over every matching asset. Exactly one of asset_id, criteria or segment_id must be provided.`,
+		},
+		"segment_id": schema.StringAttribute{
+			Optional:    true,
+			Description: `ID of a segment. Results are aggregated over every asset matching the segment criteria. Exactly one of asset_id, criteria or segment_id must be provided.`,
+		},
+		"asset_count": schema.Int64Attribute{
+			Computed:    true,
+			Description: `Number of assets the results were collected from`,
+		},
+	}
+}
+
+// resolveAssetSelection returns the assets selected by exactly one of assetID, criteria or segmentID
+func resolveAssetSelection(ctx context.Context, client *sdk.Xshield, assetID, criteria, segmentID types.String) ([]selectedAsset, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	set := 0
+	for _, v := range []types.String{assetID, criteria, segmentID} {
+		if !v.IsNull() && !v.IsUnknown() && v.ValueString() != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		diags.AddError(
+			"Invalid asset selection",
+			"Exactly one of asset_id, criteria or segment_id must be provided",
+		)
+		return nil, diags
+	}
+
+	if !assetID.IsNull() && assetID.ValueString() != "" {
+		return []selectedAsset{{ID: assetID.ValueString()}}, diags
+	}
+
+	assetCriteria := criteria.ValueString()
+	if !segmentID.IsNull() && segmentID.ValueString() != "" {
+		request := operations.GetTagBasedPolicyRequest{
+			TagbasedpolicyID: segmentID.ValueString(),
+		}
+		res, err := client.Tagbasedpolicies.GetTagBasedPolicy(ctx, request)
+		var notFound *sdkerrors.NotFoundError
+		if errors.As(err, &notFound) {
+			diags.AddError("Segment not found", fmt.Sprintf("No segment found with ID: %s", segmentID.ValueString()))
+			return nil, diags
+		}
+		if err != nil {
+			addAPIError(ctx, &diags, "failure to invoke API", err, res.GetRawResponse(), nil)
+			return nil, diags
+		}
+		if res.StatusCode != 200 || res.TagBasedPolicyResponse == nil {
+			addAPIError(ctx, &diags, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, nil)
+			return nil, diags
+		}
+		if res.TagBasedPolicyResponse.Criteria == nil || *res.TagBasedPolicyResponse.Criteria == "" {
+			diags.AddError("Segment has no criteria", fmt.Sprintf("Segment %s does not define an asset criteria", segmentID.ValueString()))
+			return nil, diags
+		}
+		assetCriteria = *res.TagBasedPolicyResponse.Criteria
+	}
+
+	assets, err := listAssetsMatchingCriteria(ctx, client, assetCriteria)
+	if err != nil {
+		diags.AddError("Error listing assets", fmt.Sprintf("Could not list assets matching criteria %q: %s", assetCriteria, err))
+		return nil, diags
+	}
+
+	tflog.Debug(ctx, "Resolved asset selection", map[string]interface{}{
+		"criteria": assetCriteria,
+		"assets":   len(assets),
+	})
+
+	return assets, diags
+}
+
+// listAssetsMatchingCriteria walks every page of ListBasicAssets for the given criteria
+func listAssetsMatchingCriteria(ctx context.Context, client *sdk.Xshield, criteria string) ([]selectedAsset, error) {
+	var assets []selectedAsset
+
+	request := operations.ListBasicAssetsRequest{
+		SearchInput: shared.SearchInput{
+			Criteria: criteria,
+		},
+	}
+	for item, err := range client.Assets.ListBasicAssetsPager(request, assetListPageSize).All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if item.AssetID == nil {
+			continue
+		}
+		assets = append(assets, selectedAsset{ID: *item.AssetID, Name: item.AssetName})
+	}
+
+	return assets, nil
+}
+
+// assetNameValue returns the asset name as a Terraform value, null when it is not known
+func (a selectedAsset) assetNameValue() types.String {
+	if a.Name == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(a.Name)
+}
+
+// assetFetchError is the error of a fetch of collectPerAsset, with the asset it failed for
+type assetFetchError struct {
+	Asset selectedAsset
+	Err   error
+}
+
+func (e *assetFetchError) Error() string {
+	return fmt.Sprintf("asset %s: %s", e.Asset.ID, e.Err)
+}
+
+func (e *assetFetchError) Unwrap() error {
+	return e.Err
+}
+
+// collectPerAsset calls fetch for every asset, assetFetchConcurrency at a time, and returns
+// the results in the order of assets. The first error cancels the remaining fetches and is
+// returned as an *assetFetchError.
+func collectPerAsset[T any](ctx context.Context, assets []selectedAsset, fetch func(ctx context.Context, asset selectedAsset) ([]T, error)) ([][]T, error) {
+	results := make([][]T, len(assets))
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(assetFetchConcurrency)
+	for i, asset := range assets {
+		group.Go(func() error {
+			items, err := fetch(ctx, asset)
+			if err != nil {
+				return &assetFetchError{Asset: asset, Err: err}
+			}
+			results[i] = items
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// addAssetFetchError reports the error returned by collectPerAsset
+func addAssetFetchError(ctx context.Context, diags *diag.Diagnostics, summary string, err error, model any) {
+	var fetchErr *assetFetchError
+	if errors.As(err, &fetchErr) {
+		summary = fmt.Sprintf("%s for asset %s", summary, fetchErr.Asset.ID)
+		err = fetchErr.Err
+	}
+	addAPIError(ctx, diags, summary, err, nil, model)
+}
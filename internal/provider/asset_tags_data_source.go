@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetTagsDataSource{}
+var _ datasource.DataSourceWithConfigure = &AssetTagsDataSource{}
+
+func NewAssetTagsDataSource() datasource.DataSource {
+	return &AssetTagsDataSource{}
+}
+
+// AssetTagsDataSource is the data source implementation.
+type AssetTagsDataSource struct {
+	client *sdk.Xshield
+}
+
+// AssetTagsDataSourceModel describes the data model.
+type AssetTagsDataSourceModel struct {
+	AssetID     types.String              `tfsdk:"asset_id"`
+	Names       []types.String            `tfsdk:"names"`
+	TagID       types.String              `tfsdk:"tag_id"`
+	Tags        []tfTypes.Tag             `tfsdk:"tags"`
+	Values      []types.String            `tfsdk:"values"`
+	ValuesByKey map[string][]types.String `tfsdk:"values_by_key"`
+}
+
+// Metadata returns the data source type name.
+func (r *AssetTagsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_tags"
+}
+
+// Schema defines the schema for the data source.
+func (r *AssetTagsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := tagListSchemaAttributes()
+	attributes["asset_id"] = schema.StringAttribute{
+		Required:    true,
+		Description: `Asset whose tags are returned`,
+	}
+	attributes["tag_id"] = schema.StringAttribute{
+		Optional:    true,
+		Description: `Only return the tag of the asset with this ID. Conflicts with names and values.`,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "AssetTags DataSource",
+
+		Attributes: attributes,
+	}
+}
+
+func (r *AssetTagsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.Xshield)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AssetTagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *AssetTagsDataSourceModel
+	var item types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &item)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(item.As(ctx, &data, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags []shared.Tag
+	if data.TagID.ValueString() != "" {
+		res, err := r.client.Tags.GetAssetTag(ctx, operations.GetAssetTagRequest{AssetID: data.AssetID.ValueString(), TagID: data.TagID.ValueString()})
+		if err != nil {
+			addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
+			return
+		}
+		if res == nil {
+			resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
+			return
+		}
+		if res.StatusCode != 200 {
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
+			return
+		}
+		if res.Tag != nil {
+			tags = append(tags, *res.Tag)
+		}
+	} else {
+		names := stringValues(data.Names)
+		values := stringValues(data.Values)
+		request := operations.ListAssetTagsRequest{
+			AssetID: data.AssetID.ValueString(),
+			Name:    names,
+			Value:   values,
+		}
+		var err error
+		tags, err = r.client.Tags.ListAssetTagsPager(request, assetListPageSize).Collect(ctx)
+		if err != nil {
+			addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, nil, data)
+			return
+		}
+	}
+
+	data.Tags, data.ValuesByKey = refreshTagsFromShared(tags)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetVulnerabilitiesDataSource{}
+var _ datasource.DataSourceWithConfigure = &AssetVulnerabilitiesDataSource{}
+
+func NewAssetVulnerabilitiesDataSource() datasource.DataSource {
+	return &AssetVulnerabilitiesDataSource{}
+}
+
+// AssetVulnerabilitiesDataSource is the data source implementation.
+type AssetVulnerabilitiesDataSource struct {
+	client *sdk.Xshield
+}
+
+// AssetVulnerabilitiesDataSourceModel describes the data model.
+type AssetVulnerabilitiesDataSourceModel struct {
+	AssetCount      types.Int64             `tfsdk:"asset_count"`
+	AssetID         types.String            `tfsdk:"asset_id"`
+	Criteria        types.String            `tfsdk:"criteria"`
+	PackageString   types.String            `tfsdk:"package_string"`
+	SegmentID       types.String            `tfsdk:"segment_id"`
+	SeverityCounts  *tfTypes.SeverityCounts `tfsdk:"severity_counts"`
+	TotalCount      types.Int64             `tfsdk:"total_count"`
+	Vulnerabilities []tfTypes.AssetCVEData  `tfsdk:"vulnerabilities"`
+}
+
+// Metadata returns the data source type name.
+func (r *AssetVulnerabilitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_vulnerabilities"
+}
+
+// Schema defines the schema for the data source.
+func (r *AssetVulnerabilitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := assetSelectionSchemaAttributes()
+	attributes["package_string"] = schema.StringAttribute{
+		Optional:    true,
+		Description: `Only return CVEs for the given package`,
+	}
+	attributes["severity_counts"] = severityCountsSchemaAttribute(`Number of distinct CVEs per severity level over all selected assets. A CVE found on several assets is counted once.`)
+	attributes["total_count"] = schema.Int64Attribute{
+		Computed:    true,
+		Description: `Number of distinct CVEs found over all selected assets`,
+	}
+	attributes["vulnerabilities"] = schema.ListNestedAttribute{
+		Computed: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"asset_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"asset_name": schema.StringAttribute{
+					Computed: true,
+				},
+				"cve_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"cvss_score": schema.Float64Attribute{
+					Computed: true,
+				},
+				"description": schema.StringAttribute{
+					Computed: true,
+				},
+				"exploit_url": schema.StringAttribute{
+					Computed: true,
+				},
+				"product": schema.StringAttribute{
+					Computed: true,
+				},
+				"severity": schema.Int64Attribute{
+					Computed:    true,
+					Description: `Severity as reported by the API`,
+				},
+				"severity_level": schema.StringAttribute{
+					Computed:    true,
+					Description: `Level of the severity reported by the API: one of critical, high, medium, low or none. It is derived from the CVSS score when the API reports no severity.`,
+				},
+				"vendor": schema.StringAttribute{
+					Computed: true,
+				},
+				"version": schema.StringAttribute{
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "AssetVulnerabilities DataSource",
+
+		Attributes: attributes,
+	}
+}
+
+func (r *AssetVulnerabilitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.Xshield)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AssetVulnerabilitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *AssetVulnerabilitiesDataSourceModel
+	var item types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &item)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(item.As(ctx, &data, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assets, diags := resolveAssetSelection(ctx, r.client, data.AssetID, data.Criteria, data.SegmentID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cvesPerAsset, err := collectPerAsset(ctx, assets, func(ctx context.Context, asset selectedAsset) ([]shared.CVEData, error) {
+		request := operations.ListVulnerabilitiesRequest{
+			AssetID: asset.ID,
+			VulnerabilitiesRequestData: shared.VulnerabilitiesRequestData{
+				PackageString: data.PackageString.ValueStringPointer(),
+			},
+		}
+		return r.client.Assets.ListVulnerabilitiesPager(request, assetListPageSize).Collect(ctx)
+	})
+	if err != nil {
+		addAssetFetchError(ctx, &resp.Diagnostics, "Error listing CVEs", err, data)
+		return
+	}
+
+	data.Vulnerabilities = []tfTypes.AssetCVEData{}
+	for i, asset := range assets {
+		data.AppendFromSharedCVEData(asset, cvesPerAsset[i])
+	}
+	data.AssetCount = types.Int64Value(int64(len(assets)))
+	data.SummarizeSeverities()
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// severityCountsSchemaAttribute returns the computed per severity level counters
+func severityCountsSchemaAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed: true,
+		Attributes: map[string]schema.Attribute{
+			"critical": schema.Int64Attribute{
+				Computed:    true,
+				Description: `Critical severity`,
+			},
+			"high": schema.Int64Attribute{
+				Computed:    true,
+				Description: `High severity`,
+			},
+			"medium": schema.Int64Attribute{
+				Computed:    true,
+				Description: `Medium severity`,
+			},
+			"low": schema.Int64Attribute{
+				Computed:    true,
+				Description: `Low severity`,
+			},
+			"none": schema.Int64Attribute{
+				Computed:    true,
+				Description: `No severity`,
+			},
+		},
+		Description: description,
+	}
+}
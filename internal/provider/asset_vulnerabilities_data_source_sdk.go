@@ -0,0 +1,106 @@
+package provider
+
+import (
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	severityLevelCritical = "critical"
+	severityLevelHigh     = "high"
+	severityLevelMedium   = "medium"
+	severityLevelLow      = "low"
+	severityLevelNone     = "none"
+)
+
+// apiSeverityLevels maps the severity reported by the API to its level
+var apiSeverityLevels = map[int64]string{
+	4: severityLevelCritical,
+	3: severityLevelHigh,
+	2: severityLevelMedium,
+	1: severityLevelLow,
+	0: severityLevelNone,
+}
+
+// severityLevel returns the level of the severity reported by the API. The CVSS v3 base score
+// is only used when the API reports no severity, or one outside of its levels.
+func severityLevel(severity *int64, score *float64) string {
+	if severity != nil {
+		if level, ok := apiSeverityLevels[*severity]; ok {
+			return level
+		}
+	}
+	return cvssSeverityLevel(score)
+}
+
+// cvssSeverityLevel maps a CVSS v3 base score to its qualitative severity rating
+func cvssSeverityLevel(score *float64) string {
+	switch {
+	case score == nil:
+		return severityLevelNone
+	case *score >= 9.0:
+		return severityLevelCritical
+	case *score >= 7.0:
+		return severityLevelHigh
+	case *score >= 4.0:
+		return severityLevelMedium
+	case *score > 0:
+		return severityLevelLow
+	default:
+		return severityLevelNone
+	}
+}
+
+// AppendFromSharedCVEData adds the CVEs of asset, skipping the ones already listed for it
+func (r *AssetVulnerabilitiesDataSourceModel) AppendFromSharedCVEData(asset selectedAsset, cves []shared.CVEData) {
+	listed := map[string]bool{}
+	for _, cveItem := range cves {
+		if cveItem.CveID != nil {
+			if listed[*cveItem.CveID] {
+				continue
+			}
+			listed[*cveItem.CveID] = true
+		}
+
+		var cve tfTypes.AssetCVEData
+		cve.AssetID = types.StringValue(asset.ID)
+		cve.AssetName = asset.assetNameValue()
+		cve.CveID = types.StringPointerValue(cveItem.CveID)
+		cve.CvssScore = types.Float64PointerValue(cveItem.Cvssscore)
+		cve.Description = types.StringPointerValue(cveItem.Description)
+		cve.ExploitURL = types.StringPointerValue(cveItem.ExploitURL)
+		cve.Product = types.StringPointerValue(cveItem.Product)
+		cve.Severity = types.Int64PointerValue(cveItem.Severity)
+		cve.SeverityLevel = types.StringValue(severityLevel(cveItem.Severity, cveItem.Cvssscore))
+		cve.Vendor = types.StringPointerValue(cveItem.Vendor)
+		cve.Version = types.StringPointerValue(cveItem.Version)
+		r.Vulnerabilities = append(r.Vulnerabilities, cve)
+	}
+}
+
+// SummarizeSeverities counts the distinct CVEs per severity level, so that a CVE found on
+// several assets is counted once. CVEs without an ID are counted on their own.
+func (r *AssetVulnerabilitiesDataSourceModel) SummarizeSeverities() {
+	counts := map[string]int64{}
+	counted := map[string]bool{}
+	var total int64
+	for _, cve := range r.Vulnerabilities {
+		if !cve.CveID.IsNull() {
+			if counted[cve.CveID.ValueString()] {
+				continue
+			}
+			counted[cve.CveID.ValueString()] = true
+		}
+		counts[cve.SeverityLevel.ValueString()]++
+		total++
+	}
+	r.SeverityCounts = &tfTypes.SeverityCounts{
+		Critical: types.Int64Value(counts[severityLevelCritical]),
+		High:     types.Int64Value(counts[severityLevelHigh]),
+		Medium:   types.Int64Value(counts[severityLevelMedium]),
+		Low:      types.Int64Value(counts[severityLevelLow]),
+		None:     types.Int64Value(counts[severityLevelNone]),
+	}
+	r.TotalCount = types.Int64Value(total)
+}
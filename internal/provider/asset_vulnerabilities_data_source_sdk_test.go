@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+)
+
+func TestSeverityLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity *int64
+		score    *float64
+		want     string
+	}{
+		{"api critical", ptr[int64](4), ptr(2.0), severityLevelCritical},
+		{"api high", ptr[int64](3), nil, severityLevelHigh},
+		{"api medium", ptr[int64](2), ptr(9.8), severityLevelMedium},
+		{"api low", ptr[int64](1), nil, severityLevelLow},
+		{"api none", ptr[int64](0), ptr(5.0), severityLevelNone},
+		{"cvss without severity", nil, ptr(9.8), severityLevelCritical},
+		{"cvss with unknown severity", ptr[int64](7), ptr(7.5), severityLevelHigh},
+		{"nothing", nil, nil, severityLevelNone},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := severityLevel(test.severity, test.score); got != test.want {
+				t.Errorf("severityLevel() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeSeveritiesCountsDistinctCVEs(t *testing.T) {
+	var data AssetVulnerabilitiesDataSourceModel
+	data.AppendFromSharedCVEData(selectedAsset{ID: "a1"}, []shared.CVEData{
+		{CveID: ptr("CVE-2024-0001"), Severity: ptr[int64](4)},
+		{CveID: ptr("CVE-2024-0002"), Severity: ptr[int64](3)},
+		{CveID: ptr("CVE-2024-0001"), Severity: ptr[int64](4)},
+	})
+	data.AppendFromSharedCVEData(selectedAsset{ID: "a2"}, []shared.CVEData{
+		{CveID: ptr("CVE-2024-0001"), Severity: ptr[int64](4)},
+		{Severity: ptr[int64](1)},
+		{Severity: ptr[int64](1)},
+	})
+	data.SummarizeSeverities()
+
+	if got := len(data.Vulnerabilities); got != 5 {
+		t.Errorf("listed %d CVEs, want 5, one per CVE of each asset", got)
+	}
+	if got := data.TotalCount.ValueInt64(); got != 4 {
+		t.Errorf("TotalCount = %d, want 4", got)
+	}
+	counts := data.SeverityCounts
+	if counts.Critical.ValueInt64() != 1 || counts.High.ValueInt64() != 1 || counts.Low.ValueInt64() != 2 {
+		t.Errorf("SeverityCounts = %+v, want 1 critical, 1 high and 2 low", counts)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
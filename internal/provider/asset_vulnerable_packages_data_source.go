@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetVulnerablePackagesDataSource{}
+var _ datasource.DataSourceWithConfigure = &AssetVulnerablePackagesDataSource{}
+
+func NewAssetVulnerablePackagesDataSource() datasource.DataSource {
+	return &AssetVulnerablePackagesDataSource{}
+}
+
+// AssetVulnerablePackagesDataSource is the data source implementation.
+type AssetVulnerablePackagesDataSource struct {
+	client *sdk.Xshield
+}
+
+// AssetVulnerablePackagesDataSourceModel describes the data model.
+type AssetVulnerablePackagesDataSourceModel struct {
+	AssetCount       types.Int64                      `tfsdk:"asset_count"`
+	AssetID          types.String                     `tfsdk:"asset_id"`
+	Criteria         types.String                     `tfsdk:"criteria"`
+	CriticalCveCount types.Int64                      `tfsdk:"critical_cve_count"`
+	HighCveCount     types.Int64                      `tfsdk:"high_cve_count"`
+	PackageCount     types.Int64                      `tfsdk:"package_count"`
+	Packages         []tfTypes.AssetVulnerablePackage `tfsdk:"packages"`
+	SegmentID        types.String                     `tfsdk:"segment_id"`
+	TotalCveCount    types.Int64                      `tfsdk:"total_cve_count"`
+}
+
+// Metadata returns the data source type name.
+func (r *AssetVulnerablePackagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_vulnerable_packages"
+}
+
+// Schema defines the schema for the data source.
+func (r *AssetVulnerablePackagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := assetSelectionSchemaAttributes()
+	attributes["critical_cve_count"] = schema.Int64Attribute{
+		Computed:    true,
+		Description: `Number of critical CVEs, summed over all vulnerable packages of all selected assets`,
+	}
+	attributes["high_cve_count"] = schema.Int64Attribute{
+		Computed:    true,
+		Description: `Number of high severity CVEs, summed over all vulnerable packages of all selected assets`,
+	}
+	attributes["package_count"] = schema.Int64Attribute{
+		Computed:    true,
+		Description: `Number of vulnerable packages found over all selected assets`,
+	}
+	attributes["packages"] = schema.ListNestedAttribute{
+		Computed: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"asset_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"asset_name": schema.StringAttribute{
+					Computed: true,
+				},
+				"critical_cve_count": schema.Int64Attribute{
+					Computed: true,
+				},
+				"cvss_score": schema.Float64Attribute{
+					Computed: true,
+				},
+				"high_cve_count": schema.Int64Attribute{
+					Computed: true,
+				},
+				"product": schema.StringAttribute{
+					Computed: true,
+				},
+				"severity": schema.Int64Attribute{
+					Computed:    true,
+					Description: `Highest severity of the package CVEs as reported by the API`,
+				},
+				"total_cve_count": schema.Int64Attribute{
+					Computed: true,
+				},
+				"vendor": schema.StringAttribute{
+					Computed: true,
+				},
+				"version": schema.StringAttribute{
+					Computed: true,
+				},
+				"vuln_source": schema.StringAttribute{
+					Computed: true,
+				},
+			},
+		},
+	}
+	attributes["total_cve_count"] = schema.Int64Attribute{
+		Computed:    true,
+		Description: `Total number of CVEs, summed over all vulnerable packages of all selected assets`,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "AssetVulnerablePackages DataSource",
+
+		Attributes: attributes,
+	}
+}
+
+func (r *AssetVulnerablePackagesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.Xshield)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AssetVulnerablePackagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *AssetVulnerablePackagesDataSourceModel
+	var item types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &item)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(item.As(ctx, &data, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assets, diags := resolveAssetSelection(ctx, r.client, data.AssetID, data.Criteria, data.SegmentID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	packagesPerAsset, err := collectPerAsset(ctx, assets, func(ctx context.Context, asset selectedAsset) ([]shared.VulnerablePackageData, error) {
+		request := operations.ListVulnerablePackagesRequest{
+			AssetID: asset.ID,
+		}
+		return r.client.Assets.ListVulnerablePackagesPager(request, assetListPageSize).Collect(ctx)
+	})
+	if err != nil {
+		addAssetFetchError(ctx, &resp.Diagnostics, "Error listing vulnerable packages", err, data)
+		return
+	}
+
+	data.Packages = []tfTypes.AssetVulnerablePackage{}
+	for i, asset := range assets {
+		data.AppendFromSharedVulnerablePackageData(asset, packagesPerAsset[i])
+	}
+	data.AssetCount = types.Int64Value(int64(len(assets)))
+	data.SummarizeCveCounts()
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
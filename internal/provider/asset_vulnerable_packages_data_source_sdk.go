@@ -0,0 +1,38 @@
+package provider
+
+import (
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (r *AssetVulnerablePackagesDataSourceModel) AppendFromSharedVulnerablePackageData(asset selectedAsset, packages []shared.VulnerablePackageData) {
+	for _, packagesItem := range packages {
+		var pkg tfTypes.AssetVulnerablePackage
+		pkg.AssetID = types.StringValue(asset.ID)
+		pkg.AssetName = asset.assetNameValue()
+		pkg.CriticalCveCount = types.Int64PointerValue(packagesItem.CriticalCveCount)
+		pkg.CvssScore = types.Float64PointerValue(packagesItem.CvssScore)
+		pkg.HighCveCount = types.Int64PointerValue(packagesItem.HighCveCount)
+		pkg.Product = types.StringPointerValue(packagesItem.Product)
+		pkg.Severity = types.Int64PointerValue(packagesItem.Severity)
+		pkg.TotalCveCount = types.Int64PointerValue(packagesItem.TotalCveCount)
+		pkg.Vendor = types.StringPointerValue(packagesItem.Vendor)
+		pkg.Version = types.StringPointerValue(packagesItem.Version)
+		pkg.VulnSource = types.StringPointerValue(packagesItem.VulnSource)
+		r.Packages = append(r.Packages, pkg)
+	}
+}
+
+func (r *AssetVulnerablePackagesDataSourceModel) SummarizeCveCounts() {
+	var critical, high, total int64
+	for _, pkg := range r.Packages {
+		critical += pkg.CriticalCveCount.ValueInt64()
+		high += pkg.HighCveCount.ValueInt64()
+		total += pkg.TotalCveCount.ValueInt64()
+	}
+	r.CriticalCveCount = types.Int64Value(critical)
+	r.HighCveCount = types.Int64Value(high)
+	r.TotalCveCount = types.Int64Value(total)
+	r.PackageCount = types.Int64Value(int64(len(r.Packages)))
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/iprange"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &CIDRNormalizeFunction{}
+var _ function.Function = &CIDRMergeFunction{}
+var _ function.Function = &IPRangeToCIDRsFunction{}
+
+func NewCIDRNormalizeFunction() function.Function {
+	return &CIDRNormalizeFunction{}
+}
+
+// CIDRNormalizeFunction returns the canonical form of an IP range
+type CIDRNormalizeFunction struct{}
+
+func (f *CIDRNormalizeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_normalize"
+}
+
+func (f *CIDRNormalizeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalize an IP range",
+		MarkdownDescription: "Returns the canonical form of `ip_range`, a CIDR, a single address or a `first-last` range of addresses: the CIDR covering the same addresses when there is one, with its host bits cleared and `/32` or `/128` for a single address, `first-last` otherwise. Ranges covering the same addresses have the same canonical form.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ip_range",
+				MarkdownDescription: "IP range to normalize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CIDRNormalizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var text string
+	resp.Error = req.Arguments.Get(ctx, &text)
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := iprange.Normalize(text)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
+
+func NewCIDRMergeFunction() function.Function {
+	return &CIDRMergeFunction{}
+}
+
+// CIDRMergeFunction aggregates IP ranges into a minimal list of CIDRs
+type CIDRMergeFunction struct{}
+
+func (f *CIDRMergeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_merge"
+}
+
+func (f *CIDRMergeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Aggregate IP ranges into a minimal list of CIDRs",
+		MarkdownDescription: "Returns the shortest list of CIDRs covering the addresses of `ip_ranges`, each a CIDR, a single address or a `first-last` range of addresses. Overlapping and adjacent ranges are merged. IPv4 CIDRs come before IPv6 ones, each in address order.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "ip_ranges",
+				ElementType:         types.StringType,
+				MarkdownDescription: "IP ranges to aggregate",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *CIDRMergeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var texts []string
+	resp.Error = req.Arguments.Get(ctx, &texts)
+	if resp.Error != nil {
+		return
+	}
+
+	prefixes, err := iprange.MergeCIDRs(texts)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, prefixStrings(prefixes))
+}
+
+func NewIPRangeToCIDRsFunction() function.Function {
+	return &IPRangeToCIDRsFunction{}
+}
+
+// IPRangeToCIDRsFunction splits an IP range into CIDRs
+type IPRangeToCIDRsFunction struct{}
+
+func (f *IPRangeToCIDRsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ip_range_to_cidrs"
+}
+
+func (f *IPRangeToCIDRsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Split an IP range into CIDRs",
+		MarkdownDescription: "Returns the shortest list of CIDRs covering `ip_range`, a `first-last` range of addresses, a CIDR or a single address, in address order.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ip_range",
+				MarkdownDescription: "IP range to split",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *IPRangeToCIDRsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var text string
+	resp.Error = req.Arguments.Get(ctx, &text)
+	if resp.Error != nil {
+		return
+	}
+
+	r, err := iprange.Parse(text)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, prefixStrings(r.Prefixes()))
+}
+
+// prefixStrings returns the CIDR notation of prefixes, an empty list when there are none
+func prefixStrings(prefixes []netip.Prefix) []string {
+	texts := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		texts = append(texts, prefix.String())
+	}
+	return texts
+}
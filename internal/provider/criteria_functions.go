@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &CriteriaFunction{}
+var _ function.Function = &QuoteFunction{}
+var _ function.Function = &CriteriaAndFunction{}
+
+func NewCriteriaFunction() function.Function {
+	return &CriteriaFunction{}
+}
+
+// CriteriaFunction builds criteria matching tag values
+type CriteriaFunction struct{}
+
+func (f *CriteriaFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "criteria"
+}
+
+func (f *CriteriaFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build criteria matching tag values",
+		MarkdownDescription: "Returns criteria matching the assets that have, for every key of `tags`, one of its values: `'key' in ('a', 'b') AND ...`, with the keys in lexical order. Keys and values are quoted and escaped. An empty map returns `*`, which matches every asset.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "tags",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "Values to match by tag key",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CriteriaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tags map[string][]string
+	resp.Error = req.Arguments.Get(ctx, &tags)
+	if resp.Error != nil {
+		return
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if len(tags[key]) == 0 {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("The values of tag %q must not be empty", key))
+			return
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, criteria.TagsIn(tags).String())
+}
+
+func NewQuoteFunction() function.Function {
+	return &QuoteFunction{}
+}
+
+// QuoteFunction quotes a value for use in criteria
+type QuoteFunction struct{}
+
+func (f *QuoteFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "quote"
+}
+
+func (f *QuoteFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Quote a value for use in criteria",
+		MarkdownDescription: "Returns `value` within single quotes, with any single quote it contains doubled, for use as a key or value in criteria.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "Value to quote",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *QuoteFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, criteria.Quote(value))
+}
+
+func NewCriteriaAndFunction() function.Function {
+	return &CriteriaAndFunction{}
+}
+
+// CriteriaAndFunction combines criteria with AND
+type CriteriaAndFunction struct{}
+
+func (f *CriteriaAndFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "criteria_and"
+}
+
+func (f *CriteriaAndFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Combine criteria with AND",
+		MarkdownDescription: "Returns criteria matching the assets matched by every criteria of `criteria`. Each criteria is kept as written, except that one whose top level is an `OR` is parenthesized. Empty criteria and `*` are skipped, `*` is returned when none is left. Criteria that cannot be parsed are an error.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "criteria",
+				ElementType:         types.StringType,
+				MarkdownDescription: "Criteria to combine",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CriteriaAndFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var texts []string
+	resp.Error = req.Arguments.Get(ctx, &texts)
+	if resp.Error != nil {
+		return
+	}
+
+	joined, err := criteria.JoinAnd(texts...)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, joined)
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction calls f with argument and returns its string result or its error
+func runFunction(t *testing.T, f function.Function, argument attr.Value) (string, *function.FuncError) {
+	t.Helper()
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{argument})}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(context.Background(), req, &resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	result, ok := resp.Result.Value().(types.String)
+	if !ok {
+		t.Fatalf("result %v is not a string", resp.Result.Value())
+	}
+	return result.ValueString(), nil
+}
+
+// tagsArgument returns the tags argument of the criteria function
+func tagsArgument(tags map[string][]string) attr.Value {
+	elements := map[string]attr.Value{}
+	for key, values := range tags {
+		list := make([]attr.Value, len(values))
+		for i, value := range values {
+			list[i] = types.StringValue(value)
+		}
+		elements[key] = types.ListValueMust(types.StringType, list)
+	}
+	return types.MapValueMust(types.ListType{ElemType: types.StringType}, elements)
+}
+
+// stringsArgument returns a list of strings argument
+func stringsArgument(values ...string) attr.Value {
+	list := make([]attr.Value, len(values))
+	for i, value := range values {
+		list[i] = types.StringValue(value)
+	}
+	return types.ListValueMust(types.StringType, list)
+}
+
+func TestCriteriaFunction(t *testing.T) {
+	tests := []struct {
+		name string
+		tags map[string][]string
+		want string
+	}{
+		{name: "empty map", tags: map[string][]string{}, want: "*"},
+		{name: "one tag", tags: map[string][]string{"app": {"web"}}, want: "'app' in ('web')"},
+		{
+			name: "sorted keys",
+			tags: map[string][]string{"env": {"prod"}, "app": {"web", "api"}},
+			want: "'app' in ('web', 'api') AND 'env' in ('prod')",
+		},
+		{name: "quoted", tags: map[string][]string{"owner's": {"it's"}}, want: "'owner''s' in ('it''s')"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := runFunction(t, NewCriteriaFunction(), tagsArgument(test.tags))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("criteria() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCriteriaFunctionEmptyValues(t *testing.T) {
+	_, err := runFunction(t, NewCriteriaFunction(), tagsArgument(map[string][]string{"app": {"web"}, "env": {}}))
+	if err == nil {
+		t.Fatal("criteria() accepted a tag without values")
+	}
+	if err.FunctionArgument == nil || *err.FunctionArgument != 0 {
+		t.Errorf("error %v is not about the tags argument", err)
+	}
+}
+
+func TestQuoteFunction(t *testing.T) {
+	tests := map[string]string{
+		"web":  "'web'",
+		"it's": "'it''s'",
+		"":     "''",
+	}
+	for value, want := range tests {
+		got, err := runFunction(t, NewQuoteFunction(), types.StringValue(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("quote(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestCriteriaAndFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria []string
+		want     string
+	}{
+		{name: "nothing", criteria: nil, want: "*"},
+		{name: "empty and all skipped", criteria: []string{"", "*", "'app' = 'web'"}, want: "'app' = 'web'"},
+		{name: "only skipped", criteria: []string{"", " * "}, want: "*"},
+		{name: "or parenthesized", criteria: []string{"a = '1' OR b = '2'", "c = '3'"}, want: "(a = '1' OR b = '2') AND c = '3'"},
+		{name: "kept as written", criteria: []string{"assetname='web'", "'app' IN ('x')"}, want: "assetname='web' AND 'app' IN ('x')"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := runFunction(t, NewCriteriaAndFunction(), stringsArgument(test.criteria...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("criteria_and() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCriteriaAndFunctionInvalidCriteria(t *testing.T) {
+	_, err := runFunction(t, NewCriteriaAndFunction(), stringsArgument("a = '1'", "b = "))
+	if err == nil {
+		t.Fatal("criteria_and() accepted criteria that cannot be parsed")
+	}
+	if err.FunctionArgument == nil || *err.FunctionArgument != 0 {
+		t.Errorf("error %v is not about the criteria argument", err)
+	}
+}
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxPreviewAssetNames is the number of added or removed asset names listed in a preview
+const maxPreviewAssetNames = 20
+
+// snapshotAsset is an asset of the snapshot with the values criteria can test, keyed by the
+// lowercase key
+type snapshotAsset struct {
+	name   string
+	values map[string][]string
+}
+
+// newSnapshotAsset returns the core tags and attributes of asset
+func newSnapshotAsset(asset shared.ExtendedAssetSummary) snapshotAsset {
+	a := snapshotAsset{name: asset.AssetName, values: map[string][]string{}}
+
+	add := func(key string, value *string) {
+		if value != nil && *value != "" {
+			key = strings.ToLower(key)
+			a.values[key] = append(a.values[key], *value)
+		}
+	}
+	add("assetName", &asset.AssetName)
+	add("assetId", asset.AssetID)
+	add("type", &asset.Type)
+	add("agentStatus", asset.AgentStatus)
+	add("assetAvailability", asset.AssetAvailability)
+	add("assetRisk", asset.AssetRisk)
+	add("businessValue", asset.BusinessValue)
+	add("clusterIdentifier", asset.ClusterIdentifier)
+	add("containerNamespace", asset.ContainerNamespace)
+	add("loggedinUser", asset.LoggedinUser)
+	add("osName", asset.OsName)
+	add("vendorInfo", asset.VendorInfo)
+	for _, networkInterface := range asset.Interfaces {
+		for _, ipAddress := range networkInterface.Ipaddresses {
+			add("ipaddresses", &ipAddress)
+		}
+	}
+	for key, value := range asset.CoreTags {
+		add(key, &value)
+	}
+	return a
+}
+
+func (a snapshotAsset) lookup(key string) []string {
+	return a.values[strings.ToLower(key)]
+}
+
+// assetSnapshot caches every asset of the tenant for a provider instance, so that plans can
+// preview which assets criteria match without a request per resource. A failed listing is
+// remembered and not attempted again. No lock is held while the assets are listed.
+type assetSnapshot struct {
+	client *sdk.Xshield
+
+	mu       sync.Mutex
+	assets   []snapshotAsset
+	keys     map[string]bool
+	err      error
+	reported bool
+}
+
+// newAssetSnapshot returns an empty asset snapshot for client
+func newAssetSnapshot(client *sdk.Xshield) *assetSnapshot {
+	return &assetSnapshot{client: client}
+}
+
+// get returns the assets, listing them when they are not cached yet, and the lowercase keys held
+// by at least one of them
+func (s *assetSnapshot) get(ctx context.Context) ([]snapshotAsset, map[string]bool, error) {
+	s.mu.Lock()
+	assets, keys, err := s.assets, s.keys, s.err
+	s.mu.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+	if keys != nil {
+		return assets, keys, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tenantLookupTimeout)
+	defer cancel()
+
+	request := operations.ListBasicAssetsRequest{
+		SearchInput: shared.SearchInput{
+			Criteria: criteria.All{}.String(),
+		},
+	}
+	keys = map[string]bool{}
+	for item, err := range s.client.Assets.ListBasicAssetsPager(request, assetListPageSize, tenantLookupOptions()...).All(ctx) {
+		if err != nil {
+			return nil, nil, s.fail(err)
+		}
+		asset := newSnapshotAsset(item)
+		for key := range asset.values {
+			keys[key] = true
+		}
+		assets = append(assets, asset)
+	}
+
+	tflog.Debug(ctx, "Listed the assets to preview criteria matches", map[string]interface{}{
+		"assets": len(assets),
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys == nil {
+		s.assets, s.keys = assets, keys
+	}
+	return s.assets, s.keys, nil
+}
+
+// fail remembers err as the failure of the snapshot, unless one is already remembered, and
+// returns the remembered failure
+func (s *assetSnapshot) fail(err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+	return s.err
+}
+
+// reportFailure returns true the first time it is called after a failure, so that the failure
+// is reported once per provider instance
+func (s *assetSnapshot) reportFailure() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil || s.reported {
+		return false
+	}
+	s.reported = true
+	return true
+}
+
+// previewCriteriaMatches warns with the number of assets the after criteria will match and the
+// assets added and removed compared to the before criteria. Nothing is reported when the
+// criteria did not change, cannot be parsed or tests keys no asset of the snapshot has, as the
+// preview would then be misleading.
+func previewCriteriaMatches(ctx context.Context, snapshot *assetSnapshot, attributePath path.Path, before, after types.String, diags *diag.Diagnostics) {
+	if snapshot == nil || after.IsNull() || after.IsUnknown() || after.Equal(before) {
+		return
+	}
+
+	afterExpr, err := criteria.Parse(after.ValueString())
+	if err != nil {
+		return
+	}
+	var beforeExpr criteria.Expr
+	if !before.IsNull() && !before.IsUnknown() {
+		if beforeExpr, err = criteria.Parse(before.ValueString()); err != nil {
+			beforeExpr = nil
+		}
+	}
+
+	assets, keys, err := snapshot.get(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Skipping the criteria preview, the assets could not be listed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		if snapshot.reportFailure() {
+			diags.AddWarning(
+				"Criteria matches not previewed",
+				fmt.Sprintf("The assets of the tenant could not be listed, so the assets criteria will match are not previewed during this plan: %s", err),
+			)
+		}
+		return
+	}
+	for _, condition := range criteria.Conditions(afterExpr) {
+		if !keys[strings.ToLower(condition.Key.Name)] {
+			tflog.Debug(ctx, "Skipping the criteria preview, no asset has a tested key", map[string]interface{}{
+				"key": condition.Key.Name,
+			})
+			return
+		}
+	}
+
+	var matched int
+	var added, removed []string
+	for _, asset := range assets {
+		matchesAfter := criteria.Match(afterExpr, asset.lookup)
+		matchesBefore := beforeExpr != nil && criteria.Match(beforeExpr, asset.lookup)
+		if matchesAfter {
+			matched++
+		}
+		switch {
+		case matchesAfter && !matchesBefore:
+			added = append(added, asset.name)
+		case matchesBefore && !matchesAfter:
+			removed = append(removed, asset.name)
+		}
+	}
+
+	detail := fmt.Sprintf("The criteria will match %d of the %d assets of the tenant", matched, len(assets))
+	if beforeExpr != nil {
+		switch difference := len(added) - len(removed); {
+		case difference > 0:
+			detail += fmt.Sprintf(", %d more than before", difference)
+		case difference < 0:
+			detail += fmt.Sprintf(", %d fewer than before", -difference)
+		default:
+			detail += ", as many as before"
+		}
+	}
+	detail += "."
+	if len(added) > 0 {
+		detail += fmt.Sprintf("\n\nAdded: %s", formatAssetNames(added))
+	}
+	if len(removed) > 0 {
+		detail += fmt.Sprintf("\n\nRemoved: %s", formatAssetNames(removed))
+	}
+	detail += "\n\nThe preview evaluates the criteria against the core tags and attributes of the assets listed during this plan, the API may match differently."
+
+	diags.AddAttributeWarning(attributePath, fmt.Sprintf("Criteria will match %d assets", matched), detail)
+}
+
+// formatAssetNames lists asset names in order, abbreviating long lists
+func formatAssetNames(names []string) string {
+	sort.Strings(names)
+	if len(names) <= maxPreviewAssetNames {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxPreviewAssetNames], ", "), len(names)-maxPreviewAssetNames)
+}
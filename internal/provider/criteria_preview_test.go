@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPreviewCriteriaMatches(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(t, w, http.StatusOK, shared.AssetSearchResults{
+			Items: []shared.ExtendedAssetSummary{
+				{AssetName: "web-1", CoreTags: map[string]string{"app": "web"}},
+				{AssetName: "web-2", CoreTags: map[string]string{"app": "WEB"}},
+				{AssetName: "db-1", CoreTags: map[string]string{"app": "db"}},
+			},
+			Metadata: &shared.PaginationSummary{Total: ptr(int64(3))},
+		})
+	}))
+	snapshot := newAssetSnapshot(client)
+	before, after := types.StringValue("'app' = 'db'"), types.StringValue("'app' = 'web'")
+
+	var diags diag.Diagnostics
+	previewCriteriaMatches(context.Background(), snapshot, path.Root("criteria"), before, after, &diags)
+	if len(diags) != 1 || diags[0].Summary() != "Criteria will match 2 assets" {
+		t.Fatalf("got diagnostics %v, want a preview of 2 assets", diags)
+	}
+	if want := "Added: web-1, web-2"; !containsLine(diags[0].Detail(), want) {
+		t.Errorf("preview %q does not list %q", diags[0].Detail(), want)
+	}
+
+	previewCriteriaMatches(context.Background(), snapshot, path.Root("criteria"), before, after, &diags)
+	if requests != 1 {
+		t.Errorf("assets listed %d times, want them cached", requests)
+	}
+}
+
+func TestPreviewCriteriaMatchesRemembersFailure(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(t, w, http.StatusInternalServerError, map[string]string{"message": "unavailable"})
+	}))
+	snapshot := newAssetSnapshot(client)
+
+	var diags diag.Diagnostics
+	for _, after := range []string{"'app' = 'web'", "'app' = 'db'"} {
+		previewCriteriaMatches(context.Background(), snapshot, path.Root("criteria"), types.StringNull(), types.StringValue(after), &diags)
+	}
+	if requests != 1 {
+		t.Errorf("assets listed %d times, want a single attempt without retries", requests)
+	}
+	if diags.HasError() || diags.WarningsCount() != 1 || diags[0].Summary() != "Criteria matches not previewed" {
+		t.Errorf("got diagnostics %v, want the failure reported once as a warning", diags)
+	}
+}
+
+func TestAssetSnapshotTimeout(t *testing.T) {
+	timeout := tenantLookupTimeout
+	tenantLookupTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { tenantLookupTimeout = timeout })
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices the client going away once the body is read
+		_, _ = io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+
+	start := time.Now()
+	if _, _, err := newAssetSnapshot(client).get(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want the listing deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("listing returned after %s", elapsed)
+	}
+}
+
+// containsLine reports whether line is one of the lines of text
+func containsLine(text, line string) bool {
+	for _, l := range strings.Split(text, "\n") {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,347 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/retry"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxListedFieldValues is the number of allowed values listed in a warning about a value
+const maxListedFieldValues = 20
+
+// tenantLookupTimeout bounds the requests made at plan time to check criteria against the
+// tenant. They only produce warnings, so they are not retried and must not hold up the plan.
+var tenantLookupTimeout = 30 * time.Second
+
+// tenantLookupOptions disables the retries of the requests made to check criteria
+func tenantLookupOptions() []operations.Option {
+	return []operations.Option{operations.WithRetries(retry.Config{Strategy: "none"})}
+}
+
+var _ validator.String = criteriaSyntaxValidator{}
+
+// criteriaSyntaxValidator reports criteria that cannot be parsed, with the position of the error
+type criteriaSyntaxValidator struct{}
+
+// criteriaSyntax returns a validator that requires a valid criteria expression
+func criteriaSyntax() validator.String {
+	return criteriaSyntaxValidator{}
+}
+
+func (v criteriaSyntaxValidator) Description(ctx context.Context) string {
+	return "value must be a valid criteria expression"
+}
+
+func (v criteriaSyntaxValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v criteriaSyntaxValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	text := req.ConfigValue.ValueString()
+	if _, err := criteria.Parse(text); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid criteria", criteriaSyntaxErrorDetail(text, err))
+	}
+}
+
+// criteriaSyntaxErrorDetail describes a syntax error, pointing at its position in single line
+// criteria
+func criteriaSyntaxErrorDetail(text string, err error) string {
+	var syntaxErr *criteria.SyntaxError
+	if !errors.As(err, &syntaxErr) || strings.ContainsAny(text, "\r\n") {
+		return fmt.Sprintf("The criteria cannot be parsed: %s", err)
+	}
+	return fmt.Sprintf("The criteria cannot be parsed: %s\n\n  %s\n  %s^", err, text, strings.Repeat(" ", syntaxErr.Column-1))
+}
+
+// tenantFields caches the asset fields of the tenant for a provider instance. The field names
+// are loaded with the first lookup, the values of a field the first time they are needed. The
+// first failed request is remembered, and no field is requested after it. No lock is held
+// while a request is in flight.
+type tenantFields struct {
+	client *sdk.Xshield
+
+	mu          sync.Mutex
+	names       map[string]string
+	descriptors map[string]*shared.MetadataColumnDescriptor
+	err         error
+	reported    bool
+}
+
+// newTenantFields returns an empty field cache for client
+func newTenantFields(client *sdk.Xshield) *tenantFields {
+	return &tenantFields{client: client, descriptors: map[string]*shared.MetadataColumnDescriptor{}}
+}
+
+// lookup returns the name of the field matching key, ignoring case. ok is false when the tenant
+// has no such field.
+func (f *tenantFields) lookup(ctx context.Context, key string) (string, bool, error) {
+	names, err := f.load(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	name, ok := names[strings.ToLower(key)]
+	return name, ok, nil
+}
+
+// load returns the field names keyed by their lowercase form, fetching them when they are not
+// cached yet
+func (f *tenantFields) load(ctx context.Context) (map[string]string, error) {
+	f.mu.Lock()
+	names, err := f.names, f.err
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if names != nil {
+		return names, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tenantLookupTimeout)
+	defer cancel()
+	res, err := f.client.Metadata.ListFields(ctx, operations.ListFieldsRequest{Scope: operations.ScopeAsset.ToPointer()}, tenantLookupOptions()...)
+	if err != nil {
+		return nil, f.fail(err)
+	}
+	if res.StatusCode != 200 {
+		return nil, f.fail(fmt.Errorf("unexpected response code %v: %s", res.StatusCode, apiErrorMessage(ctx, res.RawResponse)))
+	}
+
+	names = map[string]string{}
+	if res.TypeaheadSuggestions != nil {
+		for name := range res.TypeaheadSuggestions.Data {
+			names[strings.ToLower(name)] = name
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.names == nil {
+		f.names = names
+	}
+	return f.names, nil
+}
+
+// fail remembers err as the failure of the fields, unless one is already remembered, and
+// returns the remembered failure
+func (f *tenantFields) fail(err error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err == nil {
+		f.err = err
+	}
+	return f.err
+}
+
+// reportFailure returns true the first time it is called after a failure, so that the failure
+// is reported once per provider instance
+func (f *tenantFields) reportFailure() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err == nil || f.reported {
+		return false
+	}
+	f.reported = true
+	return true
+}
+
+// suggestion returns the field name closest to key, empty when none is close enough to be a
+// likely typo
+func (f *tenantFields) suggestion(key string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	best, bestDistance := "", len(key)/3+1
+	for lower, name := range f.names {
+		if distance := editDistance(strings.ToLower(key), lower); distance < bestDistance || distance == bestDistance && best != "" && name < best {
+			best, bestDistance = name, distance
+		}
+	}
+	return best
+}
+
+// allowedValues returns the values of the field when it only accepts a list of values. ok is
+// false for free form fields.
+func (f *tenantFields) allowedValues(ctx context.Context, name string) ([]string, bool, error) {
+	f.mu.Lock()
+	descriptor, cached := f.descriptors[name]
+	err := f.err
+	f.mu.Unlock()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !cached {
+		ctx, cancel := context.WithTimeout(ctx, tenantLookupTimeout)
+		defer cancel()
+		descriptor, err = getMetadataField(ctx, f.client, name, tenantLookupOptions()...)
+		if err != nil {
+			return nil, false, f.fail(err)
+		}
+		f.mu.Lock()
+		f.descriptors[name] = descriptor
+		f.mu.Unlock()
+	}
+	if descriptor == nil || descriptor.ListOfValues == nil || !*descriptor.ListOfValues || len(descriptor.Values) == 0 {
+		return nil, false, nil
+	}
+
+	var values []string
+	for _, value := range descriptor.Values {
+		if value.Display != nil {
+			values = append(values, *value.Display)
+		}
+		values = append(values, value.Synonyms...)
+	}
+	return values, true, nil
+}
+
+// validateCriteriaFields warns about the keys of criteria that are not fields of the tenant
+// and about values outside the list of values of their field. Syntax errors are left to
+// criteriaSyntax.
+func validateCriteriaFields(ctx context.Context, fields *tenantFields, attributePath path.Path, value types.String, diags *diag.Diagnostics) {
+	if fields == nil || value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	text := value.ValueString()
+	expr, err := criteria.Parse(text)
+	if err != nil {
+		return
+	}
+
+	for _, condition := range criteria.Conditions(expr) {
+		column := criteria.Column(text, condition.Pos)
+		if !validateTagField(ctx, fields, attributePath, condition.Key.Name, fmt.Sprintf(" at column %d", column), condition.Values, diags) {
+			return
+		}
+	}
+}
+
+// validateOnMatchFields warns about the keys of on_match that are not fields of the tenant and
+// about values outside the list of values of their field. Unknown values are not checked.
+func validateOnMatchFields(ctx context.Context, fields *tenantFields, attributePath path.Path, onMatch types.Map, diags *diag.Diagnostics) {
+	if fields == nil || onMatch.IsNull() || onMatch.IsUnknown() {
+		return
+	}
+
+	elements := onMatch.Elements()
+	keys := make([]string, 0, len(elements))
+	for key := range elements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var values []string
+		if value, ok := elements[key].(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			values = []string{value.ValueString()}
+		}
+		if !validateTagField(ctx, fields, attributePath.AtMapKey(key), key, "", values, diags) {
+			return
+		}
+	}
+}
+
+// validateTagField warns when key is not a field of the tenant or when one of values is not
+// among the values of the field. It returns false when the fields could not be retrieved, so
+// that the caller stops validating.
+func validateTagField(ctx context.Context, fields *tenantFields, attributePath path.Path, key string, position string, values []string, diags *diag.Diagnostics) bool {
+	name, known, err := fields.lookup(ctx, key)
+	if err != nil {
+		reportTenantFieldsFailure(ctx, fields, err, diags)
+		return false
+	}
+	if !known {
+		detail := fmt.Sprintf("The key %q%s is not an asset field of the tenant, so it will not match any asset.", key, position)
+		if suggestion := fields.suggestion(key); suggestion != "" {
+			detail += fmt.Sprintf(" Did you mean %q?", suggestion)
+		}
+		diags.AddAttributeWarning(attributePath, "Unknown criteria key", detail)
+		return true
+	}
+
+	allowed, ok, err := fields.allowedValues(ctx, name)
+	if err != nil {
+		reportTenantFieldsFailure(ctx, fields, err, diags)
+		return false
+	}
+	if !ok {
+		return true
+	}
+	for _, value := range values {
+		if containsFold(allowed, value) {
+			continue
+		}
+		listed := allowed
+		if len(listed) > maxListedFieldValues {
+			listed = append(listed[:maxListedFieldValues:maxListedFieldValues], "...")
+		}
+		diags.AddAttributeWarning(attributePath, "Unknown criteria value", fmt.Sprintf("The value %q of %q%s is not one of the values of the field: %s.", value, name, position, strings.Join(listed, ", ")))
+	}
+	return true
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(br)]
+}
+
+// reportTenantFieldsFailure warns, once per provider instance, that criteria are not checked as
+// the fields of the tenant could not be retrieved
+func reportTenantFieldsFailure(ctx context.Context, fields *tenantFields, err error, diags *diag.Diagnostics) {
+	tflog.Debug(ctx, "Skipping criteria validation, the tenant fields could not be retrieved", map[string]interface{}{
+		"error": err.Error(),
+	})
+	if fields.reportFailure() {
+		diags.AddWarning(
+			"Criteria not checked against the tenant",
+			fmt.Sprintf("The asset fields of the tenant could not be retrieved, so the keys and values of criteria are not checked during this plan: %s", err),
+		)
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCriteriaSyntaxErrors(t *testing.T) {
+	req := validator.StringRequest{Path: path.Root("criteria"), ConfigValue: types.StringValue("a = 'web")}
+	var resp validator.StringResponse
+	criteriaSyntax().ValidateString(context.Background(), req, &resp)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("got diagnostics %v, want one error", resp.Diagnostics)
+	}
+
+	d := resp.Diagnostics.Errors()[0]
+	assertDiagnosticPath(t, d, path.Root("criteria"))
+	if want := "column 5"; !strings.Contains(d.Detail(), want) {
+		t.Errorf("detail %q does not give the %s", d.Detail(), want)
+	}
+	if want := "\n\n  a = 'web\n      ^"; !strings.HasSuffix(d.Detail(), want) {
+		t.Errorf("detail %q does not point at the error", d.Detail())
+	}
+}
+
+func TestTenantFieldsRemembersFailure(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(t, w, http.StatusInternalServerError, map[string]string{"message": "unavailable"})
+	}))
+	fields := newTenantFields(client)
+
+	var diags diag.Diagnostics
+	for _, text := range []string{"assetname = 'web'", "'app' = 'web'"} {
+		validateCriteriaFields(context.Background(), fields, path.Root("criteria"), types.StringValue(text), &diags)
+	}
+	if requests != 1 {
+		t.Errorf("fields requested %d times, want a single attempt without retries", requests)
+	}
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("got diagnostics %v, want the failure reported once as a warning", diags)
+	}
+}
+
+func TestTenantFieldsTimeout(t *testing.T) {
+	timeout := tenantLookupTimeout
+	tenantLookupTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { tenantLookupTimeout = timeout })
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	fields := newTenantFields(client)
+
+	start := time.Now()
+	if _, _, err := fields.lookup(context.Background(), "assetname"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want the lookup deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("lookup returned after %s", elapsed)
+	}
+}
+
+func TestTenantFieldsCachesFields(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(t, w, http.StatusOK, shared.TypeaheadSuggestions{Data: map[string]shared.FieldSuggestion{"assetName": {}}})
+	}))
+	fields := newTenantFields(client)
+
+	for i := 0; i < 2; i++ {
+		name, ok, err := fields.lookup(context.Background(), "assetname")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok || name != "assetName" {
+			t.Errorf("lookup() = %q, %v, want assetName", name, ok)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("fields requested %d times, want them cached", requests)
+	}
+}
+
+func TestValidateOnMatchFieldsSkipsUnknown(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, shared.TypeaheadSuggestions{Data: map[string]shared.FieldSuggestion{"app": {}}})
+	}))
+	fields := newTenantFields(client)
+
+	var diags diag.Diagnostics
+	validateOnMatchFields(context.Background(), fields, path.Root("on_match"), types.MapUnknown(types.StringType), &diags)
+	if len(diags) != 0 {
+		t.Errorf("unknown on_match got diagnostics %v", diags)
+	}
+
+	onMatch := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"app":  types.StringUnknown(),
+		"ap_p": types.StringValue("web"),
+	})
+	validateOnMatchFields(context.Background(), fields, path.Root("on_match"), onMatch, &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("got diagnostics %v, want one warning for the unknown key", diags)
+	}
+}
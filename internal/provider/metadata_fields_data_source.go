@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MetadataFieldsDataSource{}
+var _ datasource.DataSourceWithConfigure = &MetadataFieldsDataSource{}
+
+func NewMetadataFieldsDataSource() datasource.DataSource {
+	return &MetadataFieldsDataSource{}
+}
+
+// MetadataFieldsDataSource is the data source implementation.
+type MetadataFieldsDataSource struct {
+	client *sdk.Xshield
+}
+
+// MetadataFieldsDataSourceModel describes the data model.
+type MetadataFieldsDataSourceModel struct {
+	FieldNames []types.String          `tfsdk:"field_names"`
+	Fields     []tfTypes.MetadataField `tfsdk:"fields"`
+	Name       types.String            `tfsdk:"name"`
+	Scope      types.String            `tfsdk:"scope"`
+}
+
+// Metadata returns the data source type name.
+func (r *MetadataFieldsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metadata_fields"
+}
+
+// Schema defines the schema for the data source.
+func (r *MetadataFieldsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "MetadataFields DataSource",
+
+		Attributes: map[string]schema.Attribute{
+			"field_names": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: `Names of all returned fields, sorted`,
+			},
+			"fields": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"core_tag": schema.BoolAttribute{
+							Computed: true,
+						},
+						"data_type": schema.StringAttribute{
+							Computed:    true,
+							Description: `One of String, Timestamp, Numeric, Inet, Boolean or SemVer`,
+						},
+						"display_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"facetable": schema.BoolAttribute{
+							Computed: true,
+						},
+						"list_of_values": schema.BoolAttribute{
+							Computed:    true,
+							Description: `Whether the field only accepts the listed values`,
+						},
+						"multivalued": schema.BoolAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: `Field name as used in criteria`,
+						},
+						"public_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"qualifier": schema.StringAttribute{
+							Computed: true,
+						},
+						"searchable": schema.BoolAttribute{
+							Computed: true,
+						},
+						"sortable": schema.BoolAttribute{
+							Computed: true,
+						},
+						"unit": schema.StringAttribute{
+							Computed: true,
+						},
+						"user_defined": schema.BoolAttribute{
+							Computed: true,
+						},
+						"value_suggest_enabled": schema.BoolAttribute{
+							Computed: true,
+						},
+						"values": schema.ListNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"display": schema.StringAttribute{
+										Computed: true,
+									},
+									"internal": schema.Int64Attribute{
+										Computed: true,
+									},
+									"synonyms": schema.ListAttribute{
+										Computed:    true,
+										ElementType: types.StringType,
+									},
+								},
+							},
+							Description: `Known values of the field`,
+						},
+					},
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: `Only return the field with this name`,
+			},
+			"scope": schema.StringAttribute{
+				Optional:    true,
+				Description: `must be one of ["asset", "path", "port", "agent"]`,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"asset",
+						"path",
+						"port",
+						"agent",
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *MetadataFieldsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.Xshield)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MetadataFieldsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *MetadataFieldsDataSourceModel
+	var item types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &item)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(item.As(ctx, &data, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := operations.ListFieldsRequest{}
+	if !data.Scope.IsNull() && data.Scope.ValueString() != "" {
+		request.Scope = operations.Scope(data.Scope.ValueString()).ToPointer()
+	}
+	res, err := r.client.Metadata.ListFields(ctx, request)
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
+		return
+	}
+	if res == nil {
+		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
+		return
+	}
+	if res.StatusCode != 200 {
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
+		return
+	}
+
+	var suggestions map[string]shared.FieldSuggestion
+	if res.TypeaheadSuggestions != nil {
+		suggestions = res.TypeaheadSuggestions.Data
+	}
+
+	names := make([]string, 0, len(suggestions))
+	for name := range suggestions {
+		if data.Name.ValueString() != "" && name != data.Name.ValueString() {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if data.Name.ValueString() != "" && len(names) == 0 {
+		resp.Diagnostics.AddError("Field not found", fmt.Sprintf("No field named %q was returned by the API", data.Name.ValueString()))
+		return
+	}
+
+	descriptors := make(map[string]*shared.MetadataColumnDescriptor, len(names))
+	for _, name := range names {
+		descriptor, err := getMetadataField(ctx, r.client, name)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Error retrieving field %s", name), err.Error())
+			return
+		}
+		descriptors[name] = descriptor
+	}
+
+	data.RefreshFromSharedFields(names, suggestions, descriptors)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// getMetadataField returns the column descriptor of the named field, nil when the API does
+// not know it
+func getMetadataField(ctx context.Context, client *sdk.Xshield, name string, opts ...operations.Option) (*shared.MetadataColumnDescriptor, error) {
+	res, err := client.Metadata.GetField(ctx, operations.GetFieldRequest{FieldID: name}, opts...)
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected response code %v: %s", res.StatusCode, apiErrorMessage(ctx, res.RawResponse))
+	}
+	return res.MetadataColumnDescriptor, nil
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (r *MetadataFieldsDataSourceModel) RefreshFromSharedFields(names []string, suggestions map[string]shared.FieldSuggestion, descriptors map[string]*shared.MetadataColumnDescriptor) {
+	r.FieldNames = []types.String{}
+	r.Fields = []tfTypes.MetadataField{}
+	for _, name := range names {
+		suggestion := suggestions[name]
+		descriptor := descriptors[name]
+
+		var fields1 tfTypes.MetadataField
+		fields1.Name = types.StringValue(name)
+		fields1.PublicName = types.StringPointerValue(suggestion.PublicName)
+		fields1.Qualifier = types.StringPointerValue(suggestion.Qualifier)
+		fields1.ValueSuggestEnabled = types.BoolPointerValue(suggestion.ValueSuggestEnabled)
+		if suggestion.DataType != nil {
+			fields1.DataType = types.StringValue(string(*suggestion.DataType))
+		} else {
+			fields1.DataType = types.StringNull()
+		}
+		if descriptor != nil {
+			fields1.CoreTag = types.BoolPointerValue(descriptor.CoreTag)
+			if descriptor.DataType != nil {
+				fields1.DataType = types.StringValue(string(*descriptor.DataType))
+			}
+			fields1.DisplayName = types.StringPointerValue(descriptor.DisplayName)
+			fields1.Facetable = types.BoolPointerValue(descriptor.Facetable)
+			fields1.ListOfValues = types.BoolPointerValue(descriptor.ListOfValues)
+			fields1.Multivalued = types.BoolPointerValue(descriptor.Multivalued)
+			if descriptor.Qualifier != nil {
+				fields1.Qualifier = types.StringPointerValue(descriptor.Qualifier)
+			}
+			fields1.Searchable = types.BoolPointerValue(descriptor.Searchable)
+			fields1.Sortable = types.BoolPointerValue(descriptor.Sortable)
+			fields1.Unit = types.StringPointerValue(descriptor.Unit)
+			fields1.UserDefined = types.BoolPointerValue(descriptor.UserDefined)
+			fields1.Values = []tfTypes.MetadataFieldValue{}
+			for _, valuesItem := range descriptor.Values {
+				var values1 tfTypes.MetadataFieldValue
+				values1.Display = types.StringPointerValue(valuesItem.Display)
+				values1.Internal = types.Int64PointerValue(valuesItem.Internal)
+				values1.Synonyms = []types.String{}
+				for _, v := range valuesItem.Synonyms {
+					values1.Synonyms = append(values1.Synonyms, types.StringValue(v))
+				}
+				fields1.Values = append(fields1.Values, values1)
+			}
+		} else {
+			fields1.CoreTag = types.BoolNull()
+			fields1.DisplayName = types.StringNull()
+			fields1.Facetable = types.BoolNull()
+			fields1.ListOfValues = types.BoolNull()
+			fields1.Multivalued = types.BoolNull()
+			fields1.Searchable = types.BoolNull()
+			fields1.Sortable = types.BoolNull()
+			fields1.Unit = types.StringNull()
+			fields1.UserDefined = types.BoolNull()
+		}
+
+		r.FieldNames = append(r.FieldNames, types.StringValue(name))
+		r.Fields = append(r.Fields, fields1)
+	}
+}
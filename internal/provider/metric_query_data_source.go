@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// metricQueryFunc runs an aggregate query and returns its results
+type metricQueryFunc func(ctx context.Context, client *sdk.Xshield, request shared.MetricSearchInput) (*shared.MetricResults, error)
+
+// metricQueryDataSource implements the data sources over the aggregate query endpoints, which
+// only differ by the endpoint they query
+type metricQueryDataSource struct {
+	client *sdk.Xshield
+
+	typeName            string
+	markdownDescription string
+	query               metricQueryFunc
+}
+
+// Metadata returns the data source type name.
+func (r *metricQueryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.typeName
+}
+
+// Schema defines the schema for the data source.
+func (r *metricQueryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: r.markdownDescription,
+
+		Attributes: metricQuerySchemaAttributes(),
+	}
+}
+
+func (r *metricQueryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.Xshield)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *metricQueryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *MetricQueryDataSourceModel
+	var item types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &item)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(item.As(ctx, &data, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeStart, timeEnd, err := data.timeRange(time.Now())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("lookback"), "Invalid duration", err.Error())
+		return
+	}
+
+	request := shared.MetricSearchInput{
+		Criteria:            data.Criteria.ValueString(),
+		DestinationCriteria: data.DestinationCriteria.ValueStringPointer(),
+		Scope:               data.Scope.ValueStringPointer(),
+		SourceCriteria:      data.SourceCriteria.ValueStringPointer(),
+		TimeEnd:             timeEnd,
+		TimeStart:           timeStart,
+	}
+	for _, groupByItem := range data.GroupBy {
+		request.GroupBy = append(request.GroupBy, groupByItem.ValueString())
+	}
+	for _, statisticsItem := range data.Statistics {
+		request.Statistics = append(request.Statistics, statisticsItem.ValueString())
+	}
+	results, err := r.query(ctx, r.client, request)
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, nil, data)
+		return
+	}
+	data.RefreshFromSharedMetricResults(results)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
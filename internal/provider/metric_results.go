@@ -0,0 +1,315 @@
+package provider
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/colortokens/terraform-provider-xshield/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// MetricQueryDataSourceModel describes the data model shared by the aggregate query data
+// sources.
+type MetricQueryDataSourceModel struct {
+	Criteria            types.String             `tfsdk:"criteria"`
+	DestinationCriteria types.String             `tfsdk:"destination_criteria"`
+	GroupBy             []types.String           `tfsdk:"group_by"`
+	Lookback            types.String             `tfsdk:"lookback"`
+	RawJSON             types.String             `tfsdk:"raw_json"`
+	RowCount            types.Int64              `tfsdk:"row_count"`
+	Rows                []tfTypes.MetricRow      `tfsdk:"rows"`
+	Scope               types.String             `tfsdk:"scope"`
+	SourceCriteria      types.String             `tfsdk:"source_criteria"`
+	Statistics          []types.String           `tfsdk:"statistics"`
+	TimeEnd             types.String             `tfsdk:"time_end"`
+	TimeStart           types.String             `tfsdk:"time_start"`
+	Totals              map[string]types.Float64 `tfsdk:"totals"`
+}
+
+// metricQuerySchemaAttributes returns the query inputs and flattened results shared by the
+// aggregate query data sources
+func metricQuerySchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"criteria": schema.StringAttribute{
+			Required:    true,
+			Description: `Criteria selecting the assets the query runs over`,
+		},
+		"destination_criteria": schema.StringAttribute{
+			Optional:    true,
+			Description: `Criteria selecting the destination side of the traffic`,
+		},
+		"group_by": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: `Fields to group the results by, outermost first`,
+		},
+		"lookback": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				validators.IsDuration(),
+				stringvalidator.ConflictsWith(path.MatchRoot("time_start"), path.MatchRoot("time_end")),
+			},
+			Description: `Query the given duration up to now, for example "24h". Conflicts with time_start and time_end.`,
+		},
+		"raw_json": schema.StringAttribute{
+			Computed:    true,
+			Description: `Undecoded result items as returned by the API`,
+		},
+		"row_count": schema.Int64Attribute{
+			Computed:    true,
+			Description: `Number of rows returned`,
+		},
+		"rows": schema.ListNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"group": schema.MapAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+						Description: `Group key values of the row, by group_by field`,
+					},
+					"keys": schema.ListAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+						Description: `Group key values of the row, outermost first`,
+					},
+					"statistics": schema.MapAttribute{
+						Computed:    true,
+						ElementType: types.Float64Type,
+						Description: `Statistic values of the row, by statistic name`,
+					},
+				},
+			},
+			Description: `One row per group, ordered by group keys`,
+		},
+		"scope": schema.StringAttribute{
+			Optional: true,
+		},
+		"source_criteria": schema.StringAttribute{
+			Optional:    true,
+			Description: `Criteria selecting the source side of the traffic`,
+		},
+		"statistics": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: `Statistics to compute for each group`,
+		},
+		"time_end": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				validators.IsRFC3339(),
+			},
+			Description: `End of the queried time range, in RFC3339 format`,
+		},
+		"time_start": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				validators.IsRFC3339(),
+			},
+			Description: `Start of the queried time range, in RFC3339 format`,
+		},
+		"totals": schema.MapAttribute{
+			Computed:    true,
+			ElementType: types.Float64Type,
+			Description: `Sum of each statistic over all rows`,
+		},
+	}
+}
+
+// timeRange returns the time range to query, resolving lookback against now
+func (r *MetricQueryDataSourceModel) timeRange(now time.Time) (*string, *string, error) {
+	if r.Lookback.IsNull() || r.Lookback.ValueString() == "" {
+		return r.TimeStart.ValueStringPointer(), r.TimeEnd.ValueStringPointer(), nil
+	}
+	window, err := time.ParseDuration(r.Lookback.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+	start := now.Add(-window).UTC().Format(time.RFC3339)
+	end := now.UTC().Format(time.RFC3339)
+	return &start, &end, nil
+}
+
+func (r *MetricQueryDataSourceModel) RefreshFromSharedMetricResults(resp *shared.MetricResults) {
+	var items map[string]any
+	if resp != nil {
+		items = resp.Items
+	}
+
+	raw, err := json.Marshal(items)
+	if err == nil {
+		r.RawJSON = types.StringValue(string(raw))
+	} else {
+		r.RawJSON = types.StringNull()
+	}
+
+	groupBy := make([]string, 0, len(r.GroupBy))
+	for _, field := range r.GroupBy {
+		groupBy = append(groupBy, field.ValueString())
+	}
+
+	totals := map[string]float64{}
+	r.Rows = []tfTypes.MetricRow{}
+	for _, row := range flattenMetricResults(items, groupBy) {
+		var rows1 tfTypes.MetricRow
+		rows1.Keys = make([]types.String, 0, len(row.Keys))
+		for _, key := range row.Keys {
+			rows1.Keys = append(rows1.Keys, types.StringValue(key))
+		}
+		rows1.Group = make(map[string]types.String, len(row.Group))
+		for field, value := range row.Group {
+			rows1.Group[field] = types.StringValue(value)
+		}
+		rows1.Statistics = make(map[string]types.Float64, len(row.Statistics))
+		for name, value := range row.Statistics {
+			rows1.Statistics[name] = types.Float64Value(value)
+			totals[name] += value
+		}
+		r.Rows = append(r.Rows, rows1)
+	}
+
+	r.RowCount = types.Int64Value(int64(len(r.Rows)))
+	r.Totals = make(map[string]types.Float64, len(totals))
+	for name, value := range totals {
+		r.Totals[name] = types.Float64Value(value)
+	}
+}
+
+// metricRow is one flattened row of an aggregate query result: the group key values that
+// identify the row and the statistic values computed for it
+type metricRow struct {
+	Keys       []string
+	Group      map[string]string
+	Statistics map[string]float64
+}
+
+// flattenMetricResults decodes the untyped MetricResults items returned by the aggregate
+// and network map endpoints into rows.
+//
+// Results are nested by group: every map level whose values are maps holds one group key
+// value per entry, and the innermost maps hold the statistics. A level keyed by the name
+// of a group_by field is a label naming the field of the level below it. Lists of objects
+// are flattened element by element, their string fields read as group values and their
+// numeric fields as statistics.
+func flattenMetricResults(items map[string]any, groupBy []string) []metricRow {
+	f := metricFlattener{groupBy: groupBy, fields: map[string]bool{}}
+	for _, field := range groupBy {
+		f.fields[field] = true
+	}
+	f.level(items, metricRow{Group: map[string]string{}}, "")
+
+	sort.SliceStable(f.rows, func(i, j int) bool {
+		return strings.Join(f.rows[i].Keys, "\x00") < strings.Join(f.rows[j].Keys, "\x00")
+	})
+	return f.rows
+}
+
+type metricFlattener struct {
+	groupBy []string
+	fields  map[string]bool
+	rows    []metricRow
+}
+
+// level flattens one map level. field names the group_by field its map keys belong to,
+// empty when the level was not labelled. The string values of the level are read first, so
+// that they label every row nested below it whatever the order of the keys.
+func (f *metricFlattener) level(level map[string]any, parent metricRow, field string) {
+	row := parent.copy()
+	hasStatistics := false
+
+	keys := sortedKeys(level)
+	for _, key := range keys {
+		switch v := level[key].(type) {
+		case string:
+			row.Group[key] = v
+		default:
+			if number, ok := metricNumber(v); ok {
+				row.Statistics[key] = number
+				hasStatistics = true
+			}
+		}
+	}
+
+	for _, key := range keys {
+		switch v := level[key].(type) {
+		case map[string]any:
+			if field == "" && f.fields[key] {
+				f.level(v, row, key)
+				continue
+			}
+			f.level(v, f.withKey(row, field, key), "")
+		case []any:
+			for _, element := range v {
+				if object, ok := element.(map[string]any); ok {
+					f.level(object, f.withKey(row, field, key), "")
+				}
+			}
+		}
+	}
+
+	if hasStatistics {
+		f.rows = append(f.rows, row)
+	}
+}
+
+// withKey returns a copy of parent with one more group key value. The value is recorded
+// under field, or under the next group_by field not yet assigned when field is empty.
+func (f *metricFlattener) withKey(parent metricRow, field string, key string) metricRow {
+	row := parent.copy()
+	if field == "" {
+		for _, candidate := range f.groupBy {
+			if _, ok := row.Group[candidate]; !ok {
+				field = candidate
+				break
+			}
+		}
+	}
+	if field != "" {
+		row.Group[field] = key
+	}
+	row.Keys = append(row.Keys, key)
+	return row
+}
+
+// copy returns a copy of r that can be extended without touching r
+func (r metricRow) copy() metricRow {
+	c := metricRow{
+		Keys:       append([]string{}, r.Keys...),
+		Group:      make(map[string]string, len(r.Group)),
+		Statistics: map[string]float64{},
+	}
+	for k, v := range r.Group {
+		c.Group[k] = v
+	}
+	return c
+}
+
+func metricNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
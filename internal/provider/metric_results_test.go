@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFlattenMetricResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   string
+		groupBy []string
+		want    []metricRow
+	}{
+		{
+			name:  "statistics without groups",
+			items: `{"bytes": 2048, "flows": 12}`,
+			want: []metricRow{
+				{Keys: []string{}, Group: map[string]string{}, Statistics: map[string]float64{"bytes": 2048, "flows": 12}},
+			},
+		},
+		{
+			name:    "nested group values",
+			items:   `{"web": {"inbound": {"flows": 3}, "outbound": {"flows": 1}}, "db": {"inbound": {"flows": 7}}}`,
+			groupBy: []string{"app", "direction"},
+			want: []metricRow{
+				{Keys: []string{"db", "inbound"}, Group: map[string]string{"app": "db", "direction": "inbound"}, Statistics: map[string]float64{"flows": 7}},
+				{Keys: []string{"web", "inbound"}, Group: map[string]string{"app": "web", "direction": "inbound"}, Statistics: map[string]float64{"flows": 3}},
+				{Keys: []string{"web", "outbound"}, Group: map[string]string{"app": "web", "direction": "outbound"}, Statistics: map[string]float64{"flows": 1}},
+			},
+		},
+		{
+			name:    "levels labelled by group_by field",
+			items:   `{"direction": {"inbound": {"app": {"web": {"flows": 4}}}}}`,
+			groupBy: []string{"app", "direction"},
+			want: []metricRow{
+				{Keys: []string{"inbound", "web"}, Group: map[string]string{"app": "web", "direction": "inbound"}, Statistics: map[string]float64{"flows": 4}},
+			},
+		},
+		{
+			name:    "string labels of a level apply to nested rows",
+			items:   `{"443": {"flows": 5}, "80": {"flows": 2}, "region": "us-east", "zone": "a"}`,
+			groupBy: []string{"port"},
+			want: []metricRow{
+				{Keys: []string{"443"}, Group: map[string]string{"port": "443", "region": "us-east", "zone": "a"}, Statistics: map[string]float64{"flows": 5}},
+				{Keys: []string{"80"}, Group: map[string]string{"port": "80", "region": "us-east", "zone": "a"}, Statistics: map[string]float64{"flows": 2}},
+			},
+		},
+		{
+			name:    "lists of objects",
+			items:   `{"web": [{"port": "443", "flows": 9}, {"port": "8443", "flows": 1}]}`,
+			groupBy: []string{"app", "port"},
+			want: []metricRow{
+				{Keys: []string{"web"}, Group: map[string]string{"app": "web", "port": "443"}, Statistics: map[string]float64{"flows": 9}},
+				{Keys: []string{"web"}, Group: map[string]string{"app": "web", "port": "8443"}, Statistics: map[string]float64{"flows": 1}},
+			},
+		},
+		{
+			name:  "empty results",
+			items: `{}`,
+			want:  nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var items map[string]any
+			if err := json.Unmarshal([]byte(test.items), &items); err != nil {
+				t.Fatal(err)
+			}
+			got := flattenMetricResults(items, test.groupBy)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("flattenMetricResults() =\n%+v\nwant\n%+v", got, test.want)
+			}
+		})
+	}
+}
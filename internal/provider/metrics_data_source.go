@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MetricsDataSource{}
+var _ datasource.DataSourceWithConfigure = &MetricsDataSource{}
+
+func NewMetricsDataSource() datasource.DataSource {
+	return &MetricsDataSource{metricQueryDataSource{
+		typeName:            "metrics",
+		markdownDescription: "Metrics DataSource",
+		query:               queryMetrics,
+	}}
+}
+
+// MetricsDataSource is the data source implementation.
+type MetricsDataSource struct {
+	metricQueryDataSource
+}
+
+// queryMetrics runs an aggregate query over the assets
+func queryMetrics(ctx context.Context, client *sdk.Xshield, request shared.MetricSearchInput) (*shared.MetricResults, error) {
+	res, err := client.Assets.Query(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected response code %v: %s", res.StatusCode, apiErrorMessage(ctx, res.RawResponse))
+	}
+	return res.MetricResults, nil
+}
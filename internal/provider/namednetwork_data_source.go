@@ -4,10 +4,13 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
 	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -81,7 +84,8 @@ func (r *NamedNetworkDataSource) Schema(ctx context.Context, req datasource.Sche
 							Computed: true,
 						},
 						"ip_range": schema.StringAttribute{
-							Computed: true,
+							CustomType: tfTypes.IPRangeType{},
+							Computed:   true,
 						},
 					},
 				},
@@ -177,56 +181,37 @@ func (r *NamedNetworkDataSource) Read(ctx context.Context, req datasource.ReadRe
 	} else if !data.NamedNetworkName.IsNull() && data.NamedNetworkName.ValueString() != "" {
 		// If we have a name, use ListNamedNetworks with a search criteria
 		namedNetworkName := data.NamedNetworkName.ValueString()
-		searchCriteria := fmt.Sprintf("namedNetworkName = '%s'", namedNetworkName)
+		searchCriteria := criteria.Eq(criteria.Field("namedNetworkName"), namedNetworkName).String()
 		listReq := operations.ListNamedNetworksRequest{
 			SearchInput: shared.SearchInput{
 				Criteria: searchCriteria,
 			},
 		}
 
-		// Call the API
-		networks, err := r.client.Namednetworks.ListNamedNetworks(ctx, listReq)
-		if err != nil {
+		// Search every page of the results for the name
+		network, found, listErr := r.client.Namednetworks.ListNamedNetworksPager(listReq, sdk.DefaultPageSize).Find(ctx, func(network shared.NamednetworkNamedNetwork) bool {
+			return network.NamedNetworkName != nil && *network.NamedNetworkName == namedNetworkName && network.ID != nil
+		})
+		if listErr != nil {
 			resp.Diagnostics.AddError(
 				"Error retrieving named networks",
-				fmt.Sprintf("Could not list named networks to find by name: %s", err),
+				fmt.Sprintf("Could not list named networks to find by name: %s", listErr),
 			)
 			return
 		}
-
-		// Process the response
-		if networks.NamedNetworks != nil && len(networks.NamedNetworks.Items) > 0 {
-			// Find the named network with the matching name
-			var foundID string
-			for _, network := range networks.NamedNetworks.Items {
-				if network.NamedNetworkName != nil && *network.NamedNetworkName == namedNetworkName {
-					if network.ID != nil {
-						foundID = *network.ID
-						break
-					}
-				}
-			}
-
-			if foundID != "" {
-				// Now use GetNamedNetwork to get the full details
-				request := operations.GetNamedNetworkRequest{
-					NamedNetworkID: foundID,
-				}
-				res, err = r.client.Namednetworks.GetNamedNetwork(ctx, request)
-			} else {
-				resp.Diagnostics.AddError(
-					"Named network not found",
-					fmt.Sprintf("No named network found with name: %s", namedNetworkName),
-				)
-				return
-			}
-		} else {
+		if !found {
 			resp.Diagnostics.AddError(
 				"Named network not found",
-				fmt.Sprintf("No named networks found matching the criteria: %s", searchCriteria),
+				fmt.Sprintf("No named network found with name: %s", namedNetworkName),
 			)
 			return
 		}
+
+		// Now use GetNamedNetwork to get the full details
+		request := operations.GetNamedNetworkRequest{
+			NamedNetworkID: *network.ID,
+		}
+		res, err = r.client.Namednetworks.GetNamedNetwork(ctx, request)
 	} else {
 		// If we don't have either ID or name, return an error
 		resp.Diagnostics.AddError(
@@ -235,27 +220,25 @@ func (r *NamedNetworkDataSource) Read(ctx context.Context, req datasource.ReadRe
 		)
 		return
 	}
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
 		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
 		return
 	}
-	if res.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.NamednetworkNamedNetwork != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedNamednetworkNamedNetwork(res.NamednetworkNamedNetwork)
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/colortokens/terraform-provider-xshield/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NamedNetworkHitsDataSource{}
+var _ datasource.DataSourceWithConfigure = &NamedNetworkHitsDataSource{}
+
+func NewNamedNetworkHitsDataSource() datasource.DataSource {
+	return &NamedNetworkHitsDataSource{}
+}
+
+// NamedNetworkHitsDataSource is the data source implementation.
+type NamedNetworkHitsDataSource struct {
+	client *sdk.Xshield
+}
+
+// NamedNetworkHitsDataSourceModel describes the data model.
+type NamedNetworkHitsDataSourceModel struct {
+	Criteria        types.String              `tfsdk:"criteria"`
+	HitCount        types.Int64               `tfsdk:"hit_count"`
+	Hits            []tfTypes.NamedNetworkHit `tfsdk:"hits"`
+	LastHitObserved types.String              `tfsdk:"last_hit_observed"`
+	NamedNetworkID  types.String              `tfsdk:"named_network_id"`
+	ObservedWithin  types.String              `tfsdk:"observed_within"`
+	PeerAssetIds    []types.String            `tfsdk:"peer_asset_ids"`
+}
+
+// Metadata returns the data source type name.
+func (r *NamedNetworkHitsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_named_network_hits"
+}
+
+// Schema defines the schema for the data source.
+func (r *NamedNetworkHitsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "NamedNetworkHits DataSource",
+
+		Attributes: map[string]schema.Attribute{
+			"criteria": schema.StringAttribute{
+				Optional:    true,
+				Description: `Search criteria sent to the API to narrow down the hits`,
+			},
+			"hit_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: `Number of hits returned`,
+			},
+			"hits": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"asset_id": schema.StringAttribute{
+							Computed:    true,
+							Description: `Asset that exchanged traffic with the named network`,
+						},
+						"direction": schema.StringAttribute{
+							Computed: true,
+						},
+						"domain": schema.StringAttribute{
+							Computed: true,
+						},
+						"last_hit_observed": schema.StringAttribute{
+							Computed: true,
+						},
+						"named_network_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"named_network_ip_address": schema.StringAttribute{
+							Computed: true,
+						},
+						"named_network_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"port": schema.StringAttribute{
+							Computed: true,
+						},
+						"protocol": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"last_hit_observed": schema.StringAttribute{
+				Computed:    true,
+				Description: `Most recent hit over all returned hits, in RFC3339 format. Null when there are no hits.`,
+			},
+			"named_network_id": schema.StringAttribute{
+				Optional:    true,
+				Description: `Only return hits on this named network`,
+			},
+			"observed_within": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					validators.IsDuration(),
+				},
+				Description: `Only return hits observed within this duration from now, for example "720h"`,
+			},
+			"peer_asset_ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: `Distinct assets that exchanged traffic with the named networks`,
+			},
+		},
+	}
+}
+
+func (r *NamedNetworkHitsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.Xshield)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NamedNetworkHitsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *NamedNetworkHitsDataSourceModel
+	var item types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &item)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(item.As(ctx, &data, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var since time.Time
+	if !data.ObservedWithin.IsNull() && data.ObservedWithin.ValueString() != "" {
+		window, err := time.ParseDuration(data.ObservedWithin.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("observed_within"), "Invalid duration", err.Error())
+			return
+		}
+		since = time.Now().Add(-window)
+	}
+
+	hits, err := listNamedNetworkHits(ctx, r.client, data.Criteria.ValueString(), data.NamedNetworkID.ValueString())
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		// The API answers 404 when there are no hits
+		hits, err = nil, nil
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving named network hits", err.Error())
+		return
+	}
+
+	data.RefreshFromSharedNamedNetworkHits(hits, since)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listNamedNetworkHits walks every page of ListNNHits for the given criteria. When
+// namedNetworkID is not empty the criteria is narrowed down to the hits on that named network,
+// which are the only ones returned.
+func listNamedNetworkHits(ctx context.Context, client *sdk.Xshield, hitCriteria string, namedNetworkID string) ([]shared.NamedNetworkHit, error) {
+	if namedNetworkID != "" {
+		var err error
+		hitCriteria, err = criteria.JoinAnd(hitCriteria, criteria.Eq(criteria.Field("namedNetworkId"), namedNetworkID).String())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var hits []shared.NamedNetworkHit
+	request := shared.SearchInput{
+		Criteria: hitCriteria,
+	}
+	for hit, err := range client.Namednetworks.ListNNHitsPager(request, assetListPageSize).All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if namedNetworkID != "" && (hit.NamedNetwork == nil || hit.NamedNetwork.NamedNetworkID == nil || *hit.NamedNetwork.NamedNetworkID != namedNetworkID) {
+			continue
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, nil
+}
+
+// parseHitTimestamp parses the lastHitObserved value of a hit. The API reports it either
+// as an RFC3339 timestamp or as a Unix epoch in seconds or milliseconds.
+func parseHitTimestamp(value *string) (time.Time, bool) {
+	if value == nil || *value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05Z07:00", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, *value); err == nil {
+			return t, true
+		}
+	}
+	if epoch, err := strconv.ParseInt(*value, 10, 64); err == nil {
+		// Anything past the year 33658 in seconds is a millisecond timestamp
+		if epoch > 1e12 {
+			return time.UnixMilli(epoch), true
+		}
+		return time.Unix(epoch, 0), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"sort"
+	"time"
+
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (r *NamedNetworkHitsDataSourceModel) RefreshFromSharedNamedNetworkHits(resp []shared.NamedNetworkHit, since time.Time) {
+	var lastHit time.Time
+	peers := map[string]bool{}
+
+	r.Hits = []tfTypes.NamedNetworkHit{}
+	for _, hitsItem := range resp {
+		observed, ok := parseHitTimestamp(hitsItem.LastHitObserved)
+		if ok && !since.IsZero() && observed.Before(since) {
+			continue
+		}
+		if ok && observed.After(lastHit) {
+			lastHit = observed
+		}
+		if hitsItem.AssetID != nil && *hitsItem.AssetID != "" {
+			peers[*hitsItem.AssetID] = true
+		}
+
+		var hits1 tfTypes.NamedNetworkHit
+		hits1.AssetID = types.StringPointerValue(hitsItem.AssetID)
+		hits1.Direction = types.StringPointerValue(hitsItem.Direction)
+		hits1.Domain = types.StringPointerValue(hitsItem.Domain)
+		hits1.LastHitObserved = types.StringPointerValue(hitsItem.LastHitObserved)
+		if hitsItem.NamedNetwork != nil {
+			hits1.NamedNetworkID = types.StringPointerValue(hitsItem.NamedNetwork.NamedNetworkID)
+			hits1.NamedNetworkName = types.StringPointerValue(hitsItem.NamedNetwork.NamedNetworkName)
+		} else {
+			hits1.NamedNetworkID = types.StringNull()
+			hits1.NamedNetworkName = types.StringNull()
+		}
+		hits1.NamedNetworkIPAddress = types.StringPointerValue(hitsItem.NamedNetworkIPAddress)
+		hits1.Port = types.StringPointerValue(hitsItem.Port)
+		hits1.Protocol = types.StringPointerValue(hitsItem.Protocol)
+		r.Hits = append(r.Hits, hits1)
+	}
+
+	r.HitCount = types.Int64Value(int64(len(r.Hits)))
+	r.LastHitObserved = lastHitValue(lastHit)
+	r.PeerAssetIds = sortedStringValues(peers)
+}
+
+// lastHitValue formats the most recent hit timestamp, null when no hit carried a timestamp
+func lastHitValue(lastHit time.Time) types.String {
+	if lastHit.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(lastHit.UTC().Format(time.RFC3339))
+}
+
+// sortedStringValues returns the keys of set as sorted Terraform string values
+func sortedStringValues(set map[string]bool) []types.String {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make([]types.String, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, types.StringValue(key))
+	}
+	return values
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+)
+
+func TestListNamedNetworkHitsFiltersOnTheServer(t *testing.T) {
+	var criteria []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var search shared.SearchInput
+		if err := json.NewDecoder(r.Body).Decode(&search); err != nil {
+			t.Error(err)
+		}
+		criteria = append(criteria, search.Criteria)
+		writeJSON(t, w, http.StatusOK, shared.NamedNetworkHits{
+			Items: []shared.NamedNetworkHit{
+				{AssetID: ptr("a1"), NamedNetwork: &shared.MetadataNamedNetworkReference{NamedNetworkID: ptr("nn-1")}},
+				{AssetID: ptr("a2"), NamedNetwork: &shared.MetadataNamedNetworkReference{NamedNetworkID: ptr("nn-2")}},
+			},
+		})
+	}))
+
+	hits, err := listNamedNetworkHits(context.Background(), client, "direction = 'inbound'", "nn-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "direction = 'inbound' AND namedNetworkId = 'nn-1'"; len(criteria) != 1 || criteria[0] != want {
+		t.Errorf("sent criteria %q, want [%q]", criteria, want)
+	}
+	if len(hits) != 1 || *hits[0].AssetID != "a1" {
+		t.Errorf("hits = %+v, want only the hit on nn-1", hits)
+	}
+}
+
+func TestListNamedNetworkHitsReportsNotFound(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "not found"})
+	}))
+
+	_, err := listNamedNetworkHits(context.Background(), client, "", "nn-1")
+	var notFound *sdkerrors.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("err = %v, want a *NotFoundError", err)
+	}
+}
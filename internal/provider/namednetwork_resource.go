@@ -4,15 +4,22 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
+	"github.com/colortokens/terraform-provider-xshield/internal/iprange"
 	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/colortokens/terraform-provider-xshield/internal/validators"
 	speakeasy_objectvalidators "github.com/colortokens/terraform-provider-xshield/internal/validators/objectvalidators"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -46,6 +53,7 @@ type NamedNetworkResourceModel struct {
 	NamedNetworkDescription               types.String                `tfsdk:"named_network_description"`
 	NamedNetworkName                      types.String                `tfsdk:"named_network_name"`
 	NamednetworkTagBasedPolicyAssignments types.Int64                 `tfsdk:"namednetwork_tag_based_policy_assignments"`
+	PreventDestroyIfHitWithin             types.String                `tfsdk:"prevent_destroy_if_hit_within"`
 	ProgramAsInternet                     types.Bool                  `tfsdk:"program_as_internet"`
 	ProgramAsIntranet                     types.Bool                  `tfsdk:"program_as_intranet"`
 	Region                                types.String                `tfsdk:"region"`
@@ -53,6 +61,7 @@ type NamedNetworkResourceModel struct {
 	TotalComments                         types.Int64                 `tfsdk:"total_comments"`
 	TotalCount                            types.Int64                 `tfsdk:"total_count"`
 	UsergroupNamedNetworkAssignments      types.Int64                 `tfsdk:"usergroup_named_network_assignments"`
+	Timeouts                              timeouts.Value              `tfsdk:"timeouts"`
 }
 
 func (r *NamedNetworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -87,8 +96,9 @@ func (r *NamedNetworkResource) Schema(ctx context.Context, req resource.SchemaRe
 							Computed: true,
 						},
 						"ip_range": schema.StringAttribute{
-							Computed: true,
-							Optional: true,
+							CustomType: tfTypes.IPRangeType{},
+							Computed:   true,
+							Optional:   true,
 						},
 					},
 				},
@@ -116,6 +126,13 @@ func (r *NamedNetworkResource) Schema(ctx context.Context, req resource.SchemaRe
 			"namednetwork_tag_based_policy_assignments": schema.Int64Attribute{
 				Computed: true,
 			},
+			"prevent_destroy_if_hit_within": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					validators.IsDuration(),
+				},
+				Description: `When set, deleting the named network fails if traffic hit it within this duration, for example "720h", or if the hits cannot be retrieved or dated. Only evaluated by Terraform, never sent to the API.`,
+			},
 			"program_as_internet": schema.BoolAttribute{
 				Computed: true,
 			},
@@ -140,6 +157,9 @@ func (r *NamedNetworkResource) Schema(ctx context.Context, req resource.SchemaRe
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -149,21 +169,24 @@ func (r *NamedNetworkResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.Xshield)
+	data, ok := req.ProviderData.(*resourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *NamedNetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.Plan, timeoutCreate, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *NamedNetworkResourceModel
 	var plan types.Object
 
@@ -184,10 +207,7 @@ func (r *NamedNetworkResource) Create(ctx context.Context, req resource.CreateRe
 	request := *data.ToSharedNamednetworkNamedNetwork()
 	res, err := r.client.Namednetworks.CreateNamedNetwork(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
@@ -195,11 +215,11 @@ func (r *NamedNetworkResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 	if res.StatusCode != 201 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.NamednetworkNamedNetwork != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedNamednetworkNamedNetwork(res.NamednetworkNamedNetwork)
@@ -210,6 +230,9 @@ func (r *NamedNetworkResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *NamedNetworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.State, timeoutRead, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *NamedNetworkResourceModel
 	var item types.Object
 
@@ -234,27 +257,25 @@ func (r *NamedNetworkResource) Read(ctx context.Context, req resource.ReadReques
 		NamedNetworkID: namedNetworkID,
 	}
 	res, err := r.client.Namednetworks.GetNamedNetwork(ctx, request)
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
 		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
 		return
 	}
-	if res.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.NamednetworkNamedNetwork != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedNamednetworkNamedNetwork(res.NamednetworkNamedNetwork)
@@ -264,6 +285,8 @@ func (r *NamedNetworkResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *NamedNetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.Plan, timeoutUpdate, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
 
 	var planData *NamedNetworkResourceModel
 	var stateData *NamedNetworkResourceModel
@@ -309,16 +332,17 @@ func (r *NamedNetworkResource) Update(ctx context.Context, req resource.UpdateRe
 		!planData.NamedNetworkDescription.Equal(stateData.NamedNetworkDescription)
 
 	// 2. Check for IP range changes
-	// Create maps for state and plan IP ranges by range string
+	// Create maps for state and plan IP ranges by normalized range, so that ranges covering the
+	// same addresses are not removed and added again
 	stateRangesByKey := make(map[string]tfTypes.NamednetworkRange)
 	for _, stateRange := range stateData.IPRanges {
-		key := stateRange.IPRange.ValueString()
+		key := ipRangeKey(stateRange.IPRange.ValueString())
 		stateRangesByKey[key] = stateRange
 	}
 
 	planRangesByKey := make(map[string]tfTypes.NamednetworkRange)
 	for _, planRange := range planData.IPRanges {
-		key := planRange.IPRange.ValueString()
+		key := ipRangeKey(planRange.IPRange.ValueString())
 		planRangesByKey[key] = planRange
 	}
 
@@ -342,14 +366,12 @@ func (r *NamedNetworkResource) Update(ctx context.Context, req resource.UpdateRe
 		}
 	}
 
-	// Check for ranges that exist in both state and plan but have different attributes
-	// This should never happen for IP ranges as the key is the range itself, but we include it for completeness
+	// Ranges that exist in both state and plan may be written differently, such as 10.0.0.1/8
+	// and 10.0.0.0/8, they cover the same addresses so they are left as they are
 	for key, planRange := range planRangesByKey {
 		if stateRange, ok := stateRangesByKey[key]; ok {
-			// This range exists in both state and plan, but they should be identical
-			// If they're not, something unexpected has happened
 			if !planRange.IPRange.Equal(stateRange.IPRange) {
-				tflog.Warn(ctx, fmt.Sprintf("IP range %s has changed attributes but should be identical", key))
+				tflog.Debug(ctx, fmt.Sprintf("IP range %s is written %s in state, keeping it", planRange.IPRange.ValueString(), stateRange.IPRange.ValueString()))
 			}
 		}
 	}
@@ -371,19 +393,13 @@ func (r *NamedNetworkResource) Update(ctx context.Context, req resource.UpdateRe
 		// Call the API to update metadata
 		res, err := r.client.Namednetworks.UpdateNamedNetworkMetadata(ctx, metadataRequest)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to update named network metadata", err.Error())
-			if res != nil && res.RawResponse != nil {
-				resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-			}
+			addAPIError(ctx, &resp.Diagnostics, "Failed to update named network metadata", err, res.GetRawResponse(), data)
 			return
 		}
 
 		// Check for non-success status code
 		if res != nil && res.StatusCode != 204 {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode),
-				debugResponse(res.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 			return
 		}
 
@@ -417,32 +433,14 @@ func (r *NamedNetworkResource) Update(ctx context.Context, req resource.UpdateRe
 		// Call the API to remove ranges
 		res, err := r.client.Namednetworks.DeleteFromNamedNetwork(ctx, removeRequest)
 
-		// Handle the specific error for 202/204 status codes
 		if err != nil {
-			tflog.Debug(ctx, fmt.Sprintf("Error from DeleteFromNamedNetwork: %s", err.Error()))
-
-			// Check if the error message contains "Status 202" or "Status 204"
-			if strings.Contains(err.Error(), "Status 202") || strings.Contains(err.Error(), "Status 204") {
-				// This is actually a success, so we'll continue
-				tflog.Debug(ctx, "Detected 202/204 status code in error, treating as success")
-			} else {
-				// For any other error, report it
-				resp.Diagnostics.AddError("Failed to remove IP ranges", err.Error())
-				if res != nil && res.RawResponse != nil {
-					resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-				}
-				return
-			}
-		} else if res != nil {
-			// If no error, check the status code
-			tflog.Debug(ctx, fmt.Sprintf("DeleteFromNamedNetwork returned status code: %d", res.StatusCode))
-			if res.StatusCode != 202 && res.StatusCode != 204 && res.StatusCode != 200 {
-				resp.Diagnostics.AddError(
-					fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode),
-					debugResponse(res.RawResponse),
-				)
-				return
-			}
+			addAPIError(ctx, &resp.Diagnostics, "Failed to remove IP ranges", err, res.GetRawResponse(), data)
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("DeleteFromNamedNetwork returned status code: %d", res.StatusCode))
+		if res.StatusCode != 202 && res.StatusCode != 204 && res.StatusCode != 200 {
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
+			return
 		}
 	}
 
@@ -469,32 +467,14 @@ func (r *NamedNetworkResource) Update(ctx context.Context, req resource.UpdateRe
 		// Call the API to add ranges
 		res, err := r.client.Namednetworks.AddToNamedNetwork(ctx, addRequest)
 
-		// Handle the specific error for 202/204 status codes
 		if err != nil {
-			tflog.Debug(ctx, fmt.Sprintf("Error from AddToNamedNetwork: %s", err.Error()))
-
-			// Check if the error message contains "Status 202" or "Status 204"
-			if strings.Contains(err.Error(), "Status 202") || strings.Contains(err.Error(), "Status 204") {
-				// This is actually a success, so we'll continue
-				tflog.Debug(ctx, "Detected 202/204 status code in error, treating as success")
-			} else {
-				// For any other error, report it
-				resp.Diagnostics.AddError("Failed to add IP ranges", err.Error())
-				if res != nil && res.RawResponse != nil {
-					resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-				}
-				return
-			}
-		} else if res != nil {
-			// If no error, check the status code
-			tflog.Debug(ctx, fmt.Sprintf("AddToNamedNetwork returned status code: %d", res.StatusCode))
-			if res.StatusCode != 202 && res.StatusCode != 204 && res.StatusCode != 200 {
-				resp.Diagnostics.AddError(
-					fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode),
-					debugResponse(res.RawResponse),
-				)
-				return
-			}
+			addAPIError(ctx, &resp.Diagnostics, "Failed to add IP ranges", err, res.GetRawResponse(), data)
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("AddToNamedNetwork returned status code: %d", res.StatusCode))
+		if res.StatusCode != 202 && res.StatusCode != 204 && res.StatusCode != 200 {
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
+			return
 		}
 	}
 
@@ -509,20 +489,14 @@ func (r *NamedNetworkResource) Update(ctx context.Context, req resource.UpdateRe
 		readRes, err := r.client.Namednetworks.GetNamedNetwork(ctx, readRequest)
 		if err != nil {
 			tflog.Error(ctx, fmt.Sprintf("Error reading updated named network: %s", err.Error()))
-			resp.Diagnostics.AddError("Failed to read updated named network", err.Error())
-			if readRes != nil && readRes.RawResponse != nil {
-				resp.Diagnostics.AddError("unexpected http request/response", debugResponse(readRes.RawResponse))
-			}
+			addAPIError(ctx, &resp.Diagnostics, "Failed to read updated named network", err, readRes.GetRawResponse(), data)
 			return
 		}
 
 		// Check for non-success status code
 		if readRes != nil && readRes.StatusCode != 200 {
 			tflog.Error(ctx, fmt.Sprintf("Unexpected status code from GetNamedNetwork: %d", readRes.StatusCode))
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", readRes.StatusCode),
-				debugResponse(readRes.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", readRes.StatusCode), nil, readRes.RawResponse, data)
 			return
 		}
 
@@ -546,6 +520,9 @@ func (r *NamedNetworkResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *NamedNetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.State, timeoutDelete, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *NamedNetworkResourceModel
 	var item types.Object
 
@@ -566,15 +543,65 @@ func (r *NamedNetworkResource) Delete(ctx context.Context, req resource.DeleteRe
 	var namedNetworkID string
 	namedNetworkID = data.ID.ValueString()
 
+	// Refuse to delete a named network that still sees traffic
+	if !data.PreventDestroyIfHitWithin.IsNull() && data.PreventDestroyIfHitWithin.ValueString() != "" {
+		window, err := time.ParseDuration(data.PreventDestroyIfHitWithin.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("prevent_destroy_if_hit_within"), "Invalid duration", err.Error())
+			return
+		}
+
+		// Any doubt about the traffic, such as a failed request or a hit whose time cannot be
+		// parsed, refuses the delete
+		hits, err := listNamedNetworkHits(ctx, r.client, "", namedNetworkID)
+		var notFound *sdkerrors.NotFoundError
+		if errors.As(err, &notFound) {
+			// The API answers 404 when the named network was never hit
+			hits, err = nil, nil
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error retrieving named network hits", fmt.Sprintf("Could not check whether named network %s still sees traffic: %s", namedNetworkID, err))
+			return
+		}
+
+		since := time.Now().Add(-window)
+		for _, hit := range hits {
+			observed, ok := parseHitTimestamp(hit.LastHitObserved)
+			if !ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("prevent_destroy_if_hit_within"),
+					"Cannot tell when named network was last hit",
+					fmt.Sprintf("Named network %s was hit at an unknown time %q (asset %s, %s %s/%s), so it may still see traffic. Remove prevent_destroy_if_hit_within to delete it anyway.",
+						data.NamedNetworkName.ValueString(), stringOrUnknown(hit.LastHitObserved),
+						stringOrUnknown(hit.AssetID), stringOrUnknown(hit.Direction), stringOrUnknown(hit.Protocol), stringOrUnknown(hit.Port)),
+				)
+				return
+			}
+			if observed.Before(since) {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root("prevent_destroy_if_hit_within"),
+				"Named network still sees traffic",
+				fmt.Sprintf("Named network %s was hit at %s, within the last %s (asset %s, %s %s/%s). Remove prevent_destroy_if_hit_within or wait for the traffic to stop before deleting it.",
+					data.NamedNetworkName.ValueString(), observed.UTC().Format(time.RFC3339), window,
+					stringOrUnknown(hit.AssetID), stringOrUnknown(hit.Direction), stringOrUnknown(hit.Protocol), stringOrUnknown(hit.Port)),
+			)
+			return
+		}
+		tflog.Debug(ctx, "No recent named network hits, proceeding with delete", map[string]interface{}{
+			"id":     namedNetworkID,
+			"hits":   len(hits),
+			"window": window.String(),
+		})
+	}
+
 	request := operations.DeleteNamedNetworkRequest{
 		NamedNetworkID: namedNetworkID,
 	}
 	res, err := r.client.Namednetworks.DeleteNamedNetwork(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
@@ -582,7 +609,7 @@ func (r *NamedNetworkResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 	if res.StatusCode != 202 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 
@@ -598,7 +625,7 @@ func (r *NamedNetworkResource) ImportState(ctx context.Context, req resource.Imp
 
 	// If not a UUID, assume it's a name and look up the named network
 	// Create a search criteria that filters by the named network name
-	searchCriteria := fmt.Sprintf("namedNetworkName = '%s'", req.ID)
+	searchCriteria := criteria.Eq(criteria.Field("namedNetworkName"), req.ID).String()
 	listReq := operations.ListNamedNetworksRequest{
 		SearchInput: shared.SearchInput{
 			Criteria: searchCriteria,
@@ -611,9 +638,10 @@ func (r *NamedNetworkResource) ImportState(ctx context.Context, req resource.Imp
 		"search_criteria": listReq.SearchInput.Criteria,
 	})
 
-	// Try to get the named networks
-	networks, err := r.client.Namednetworks.ListNamedNetworks(ctx, listReq)
-
+	// Search every page of the named networks
+	network, found, err := r.client.Namednetworks.ListNamedNetworksPager(listReq, sdk.DefaultPageSize).Find(ctx, func(network shared.NamednetworkNamedNetwork) bool {
+		return network.NamedNetworkName != nil && *network.NamedNetworkName == req.ID && network.ID != nil
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving named networks",
@@ -622,27 +650,13 @@ func (r *NamedNetworkResource) ImportState(ctx context.Context, req resource.Imp
 		return
 	}
 
-	// Process the JSON response
-	if networks.NamedNetworks != nil && len(networks.NamedNetworks.Items) > 0 {
-		// Find the named network with the matching name
-		var foundID string
-		for _, network := range networks.NamedNetworks.Items {
-			if network.NamedNetworkName != nil && *network.NamedNetworkName == req.ID {
-				if network.ID != nil {
-					foundID = *network.ID
-					break
-				}
-			}
-		}
-
-		if foundID != "" {
-			tflog.Info(ctx, "Found named network", map[string]interface{}{
-				"id":   foundID,
-				"name": req.ID,
-			})
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), foundID)...)
-			return
-		}
+	if found {
+		tflog.Info(ctx, "Found named network", map[string]interface{}{
+			"id":   *network.ID,
+			"name": req.ID,
+		})
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), *network.ID)...)
+		return
 	}
 
 	resp.Diagnostics.AddError(
@@ -651,6 +665,23 @@ func (r *NamedNetworkResource) ImportState(ctx context.Context, req resource.Imp
 	)
 }
 
+// stringOrUnknown dereferences an optional API string for use in messages
+func stringOrUnknown(s *string) string {
+	if s == nil || *s == "" {
+		return "unknown"
+	}
+	return *s
+}
+
+// ipRangeKey returns the canonical form of an IP range to compare ranges by the addresses they
+// cover, or the range as written when it cannot be parsed
+func ipRangeKey(s string) string {
+	if normalized, err := iprange.Normalize(s); err == nil {
+		return normalized
+	}
+	return s
+}
+
 // Helper to check if a string is a UUID
 func isUUID(s string) bool {
 	// Simple UUID format check (not comprehensive)
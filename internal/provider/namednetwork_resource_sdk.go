@@ -152,7 +152,7 @@ func (r *NamedNetworkResourceModel) RefreshFromSharedNamednetworkNamedNetwork(re
 			var ipRanges1 tfTypes.NamednetworkRange
 			ipRanges1.ID = types.StringPointerValue(ipRangesItem.ID)
 			ipRanges1.IPCount = types.Int64PointerValue(ipRangesItem.IPCount)
-			ipRanges1.IPRange = types.StringPointerValue(ipRangesItem.IPRange)
+			ipRanges1.IPRange = tfTypes.NewIPRangePointerValue(ipRangesItem.IPRange)
 			if ipRangesCount+1 > len(r.IPRanges) {
 				r.IPRanges = append(r.IPRanges, ipRanges1)
 			} else {
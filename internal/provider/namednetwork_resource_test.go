@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// resourceState returns a state of the schema of r holding values, every other attribute null
+func resourceState(t *testing.T, r resource.Resource, values map[string]tftypes.Value) tfsdk.State {
+	t.Helper()
+
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema diagnostics: %v", resp.Diagnostics)
+	}
+	objectType := resp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	return tfsdk.State{Schema: resp.Schema, Raw: objectWith(objectType, values)}
+}
+
+// objectWith returns an object of objectType holding values, every other attribute null
+func objectWith(objectType tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+			continue
+		}
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+func TestNamedNetworkDeletePreventDestroyIfHitWithin(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		hitAgo  time.Duration
+		deleted bool
+	}{
+		{name: "no hits", status: http.StatusNotFound, deleted: true},
+		{name: "hit outside the window", status: http.StatusOK, hitAgo: 48 * time.Hour, deleted: true},
+		{name: "hit inside the window", status: http.StatusOK, hitAgo: 5 * time.Minute, deleted: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deleted := false
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/api/namednetworks/hits":
+					if test.status != http.StatusOK {
+						writeJSON(t, w, test.status, map[string]string{"message": "no hits"})
+						return
+					}
+					writeJSON(t, w, http.StatusOK, shared.NamedNetworkHits{
+						Items: []shared.NamedNetworkHit{{
+							AssetID:         ptr("a1"),
+							LastHitObserved: ptr(time.Now().Add(-test.hitAgo).UTC().Format(time.RFC3339)),
+							NamedNetwork:    &shared.MetadataNamedNetworkReference{NamedNetworkID: ptr("nn-1")},
+						}},
+					})
+				case r.Method == http.MethodDelete && r.URL.Path == "/api/namednetworks/nn-1":
+					deleted = true
+					w.WriteHeader(http.StatusAccepted)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotImplemented)
+				}
+			}))
+
+			r := &NamedNetworkResource{client: client}
+			req := resource.DeleteRequest{State: resourceState(t, r, map[string]tftypes.Value{
+				"id":                            tftypes.NewValue(tftypes.String, "nn-1"),
+				"named_network_name":            tftypes.NewValue(tftypes.String, "office"),
+				"prevent_destroy_if_hit_within": tftypes.NewValue(tftypes.String, "1h"),
+			})}
+			resp := resource.DeleteResponse{State: req.State}
+			r.Delete(context.Background(), req, &resp)
+
+			if test.deleted && resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if !test.deleted && !resp.Diagnostics.HasError() {
+				t.Fatal("deleted a named network hit within the window")
+			}
+			if deleted != test.deleted {
+				t.Errorf("delete request sent = %v, want %v", deleted, test.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NetworkMapDataSource{}
+var _ datasource.DataSourceWithConfigure = &NetworkMapDataSource{}
+
+func NewNetworkMapDataSource() datasource.DataSource {
+	return &NetworkMapDataSource{metricQueryDataSource{
+		typeName:            "network_map",
+		markdownDescription: "NetworkMap DataSource",
+		query:               queryNetworkMap,
+	}}
+}
+
+// NetworkMapDataSource is the data source implementation.
+type NetworkMapDataSource struct {
+	metricQueryDataSource
+}
+
+// queryNetworkMap runs an aggregate query over the network map
+func queryNetworkMap(ctx context.Context, client *sdk.Xshield, request shared.MetricSearchInput) (*shared.MetricResults, error) {
+	res, err := client.Assets.NetworkQuery(ctx, shared.NetworkMapSearchInput(request))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected response code %v: %s", res.StatusCode, apiErrorMessage(ctx, res.RawResponse))
+	}
+	return res.MetricResults, nil
+}
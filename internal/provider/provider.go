@@ -4,26 +4,38 @@ package provider
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/retry"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ provider.Provider = &XshieldProvider{}
+var _ provider.ProviderWithFunctions = &XshieldProvider{}
+
+// defaultLogBodyLimit is the number of bytes of a body logged when log_body_limit is not set
+const defaultLogBodyLimit = 4096
 
 type XshieldProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -35,12 +47,26 @@ type XshieldProvider struct {
 // XshieldProviderModel describes the provider data model.
 type XshieldProviderModel struct {
 	ServerURL          types.String  `tfsdk:"server_url"`
+	Region             types.String  `tfsdk:"region"`
 	TenancyId          types.String  `tfsdk:"tenancy_id"`
 	PrincipalId        types.String  `tfsdk:"user_id"`
 	FingerPrint        types.String  `tfsdk:"fingerprint"`
 	PrivateKeyLocation types.String  `tfsdk:"private_key_path"`
+	PrivateKey         types.String  `tfsdk:"private_key"`
+	PrivateKeyPass     types.String  `tfsdk:"private_key_passphrase"`
+	Profile            types.String  `tfsdk:"profile"`
+	CredentialProcess  types.String  `tfsdk:"credential_process"`
 	ProxyURL           types.String  `tfsdk:"proxy_url"`
 	ProxyCreds         types.String  `tfsdk:"proxy_creds"`
+	CACertFile         types.String  `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String  `tfsdk:"ca_cert_pem"`
+	ClientCertFile     types.String  `tfsdk:"client_cert_file"`
+	ClientCertPEM      types.String  `tfsdk:"client_cert_pem"`
+	ClientKeyFile      types.String  `tfsdk:"client_key_file"`
+	ClientKeyPEM       types.String  `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool    `tfsdk:"insecure_skip_verify"`
+	LogBodyLimit       types.Int64   `tfsdk:"log_body_limit"`
+	RequestsPerSecond  types.Float64 `tfsdk:"requests_per_second"`
 	RequestTimeout     types.Int64   `tfsdk:"request_timeout"`
 	InitialInterval    types.Int64   `tfsdk:"initial_interval"`
 	MaxInterval        types.Int64   `tfsdk:"max_interval"`
@@ -58,54 +84,143 @@ func (p *XshieldProvider) Schema(ctx context.Context, req provider.SchemaRequest
 		Description: `ColorTokens Core API: API for managing lifecycle of core micro-segmentation resources (tags, assets & groups)`,
 		Attributes: map[string]schema.Attribute{
 			"server_url": schema.StringAttribute{
-				MarkdownDescription: "Server URL (defaults to https://ng.colortokens.com)",
+				MarkdownDescription: "Server URL (defaults to https://ng.colortokens.com). May also be set with the `XSHIELD_SERVER_URL` environment variable.",
 				Optional:            true,
 				Required:            false,
 			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the tenant, selecting its server: `iad` (US, the default), `bom` (India), `fra` (Europe) or `syd` (Australia). Conflicts with `server_url`. May also be set with the `XSHIELD_REGION` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(serverNames()...),
+					stringvalidator.ConflictsWith(path.MatchRoot("server_url")),
+				},
+			},
 			"tenancy_id": schema.StringAttribute{
-				Required:  true,
-				Sensitive: false,
+				Optional:            true,
+				Sensitive:           false,
+				MarkdownDescription: "Tenancy ID. May also be set with the `XSHIELD_TENANCY_ID` environment variable.",
 			},
 			"user_id": schema.StringAttribute{
-				Required:  true,
-				Sensitive: false,
+				Optional:            true,
+				Sensitive:           false,
+				MarkdownDescription: "User ID. May also be set with the `XSHIELD_USER_ID` environment variable.",
 			}, "fingerprint": schema.StringAttribute{
-				Required:  true,
-				Sensitive: false,
+				Optional:            true,
+				Sensitive:           false,
+				MarkdownDescription: "Fingerprint of the API key as shown in the console, for example `12:34:56:78:90:ab:cd:ef:12:34:56:78:90:ab:cd:ef`. It is checked against the private key when the provider is configured. May also be set with the `XSHIELD_FINGERPRINT` environment variable.",
 			}, "private_key_path": schema.StringAttribute{
-				Required:  true,
-				Sensitive: false,
+				Optional:            true,
+				Sensitive:           false,
+				MarkdownDescription: "Path to the PEM encoded private key of the API key. May also be set with the `XSHIELD_PRIVATE_KEY_PATH` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("private_key")),
+				},
+			},
+			"private_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "PEM encoded private key of the API key, as an alternative to `private_key_path`. May also be set with the `XSHIELD_PRIVATE_KEY` environment variable.",
+			},
+			"private_key_passphrase": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Passphrase of an encrypted private key. Encrypted PKCS#8 and legacy encrypted PEM keys are supported. May also be set with the `XSHIELD_PRIVATE_KEY_PASSPHRASE` environment variable.",
+			},
+			"credential_process": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Command that prints the signing material as JSON with the keys `tenancy`, `user`, `fingerprint`, `private_key` and optionally `private_key_passphrase` and `expiration` (RFC3339). The output is cached and the command is run again shortly before the expiration. Replaces `tenancy_id`, `user_id`, `fingerprint` and the private key settings. May also be set with the `XSHIELD_CREDENTIAL_PROCESS` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("private_key"), path.MatchRoot("private_key_path")),
+				},
+			},
+			"profile": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Profile of the `~/.xshield/config` file to read settings from. Settings in the provider configuration and `XSHIELD_*` environment variables take precedence over the profile. May also be set with the `XSHIELD_PROFILE` environment variable, and the file location with `XSHIELD_CONFIG_FILE`.",
 			},
 			// HTTP request config
 			"request_timeout": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "HTTP request timeout in seconds (defaults to 60)",
+				MarkdownDescription: "HTTP request timeout in seconds (defaults to 60). May also be set with the `XSHIELD_REQUEST_TIMEOUT` environment variable.",
 			},
 			"initial_interval": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "Initial interval for backoff strategy in miliseconds (defaults to 500)",
+				MarkdownDescription: "Initial interval for backoff strategy in miliseconds (defaults to 500). May also be set with the `XSHIELD_INITIAL_INTERVAL` environment variable.",
 			},
 			"max_interval": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "Maximum interval for backoff strategy in seconds (defaults to 60)",
+				MarkdownDescription: "Maximum interval for backoff strategy in seconds (defaults to 60). May also be set with the `XSHIELD_MAX_INTERVAL` environment variable.",
 			},
 			"max_elapsed_time": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "Maximum elapsed time for backoff strategy in seconds (defaults to 3600000)",
+				MarkdownDescription: "Maximum elapsed time for backoff strategy in seconds (defaults to 3600000). May also be set with the `XSHIELD_MAX_ELAPSED_TIME` environment variable.",
 			},
 			"exponent": schema.Float64Attribute{
 				Optional:            true,
-				MarkdownDescription: "Exponent for backoff strategy (defaults to 1.5)",
+				MarkdownDescription: "Exponent for backoff strategy (defaults to 1.5). May also be set with the `XSHIELD_EXPONENT` environment variable.",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum rate of API requests sent by the provider, shared by all resources and data sources and including retries (not limited by default). Rate limited responses (HTTP 429) are retried after the `Retry-After` delay in any case. May also be set with the `XSHIELD_REQUESTS_PER_SECOND` environment variable.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.01),
+				},
+			},
+			"log_body_limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of bytes of each request and response body written to the log when `TF_LOG` or `TF_LOG_PROVIDER` is `DEBUG` or `TRACE` (defaults to 4096). Longer bodies are truncated and 0 leaves bodies out of the log. May also be set with the `XSHIELD_LOG_BODY_LIMIT` environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			// Proxy config
 			"proxy_url": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "HTTP proxy URL (format: http://proxy-ip:port)",
+				MarkdownDescription: "HTTP proxy URL (format: http://proxy-ip:port). May also be set with the `XSHIELD_PROXY_URL` environment variable.",
 			},
 			"proxy_creds": schema.StringAttribute{
 				Optional:            true,
 				Sensitive:           true,
-				MarkdownDescription: "Proxy credentials (format: username:password)",
+				MarkdownDescription: "Proxy credentials (format: username:password). May also be set with the `XSHIELD_PROXY_CREDS` environment variable.",
+			},
+			// TLS config
+			"ca_cert_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to PEM encoded CA certificates trusted in addition to the system roots, for example those of a TLS intercepting proxy. May also be set with the `XSHIELD_CA_CERT_FILE` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem")),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "PEM encoded CA certificates trusted in addition to the system roots, as an alternative to `ca_cert_file`. May also be set with the `XSHIELD_CA_CERT_PEM` environment variable.",
+			},
+			"client_cert_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to the PEM encoded client certificate presented for mutual TLS. Requires `client_key_file` or `client_key_pem`. May also be set with the `XSHIELD_CLIENT_CERT_FILE` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_cert_pem")),
+				},
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "PEM encoded client certificate presented for mutual TLS, as an alternative to `client_cert_file`. May also be set with the `XSHIELD_CLIENT_CERT_PEM` environment variable.",
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to the PEM encoded private key of the client certificate. May also be set with the `XSHIELD_CLIENT_KEY_FILE` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_key_pem": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "PEM encoded private key of the client certificate, as an alternative to `client_key_file`. May also be set with the `XSHIELD_CLIENT_KEY_PEM` environment variable.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Skip verification of the server certificate chain and host name (defaults to false). Only meant for lab environments with self-signed certificates, never for production. May also be set with the `XSHIELD_INSECURE_SKIP_VERIFY` environment variable.",
 			},
 		},
 	}
@@ -120,13 +235,31 @@ func (p *XshieldProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	ServerURL := data.ServerURL.ValueString()
+	applyEnvironment(&data, &resp.Diagnostics)
 
-	if ServerURL == "" {
-		ServerURL = "https://ng.colortokens.com"
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	config := buildConfigProvider(data, resp)
+	profile := loadProfile(data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applyProfile(&data, profile, &resp.Diagnostics)
+
+	serverOption := resolveServer(ctx, data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := buildConfigProvider(ctx, data, profile, resp)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Create custom HTTP client with provider configuration
 	httpClient, err := newHTTPClientWithOptions(data)
@@ -139,7 +272,7 @@ func (p *XshieldProvider) Configure(ctx context.Context, req provider.ConfigureR
 	}
 
 	opts := []sdk.SDKOption{
-		sdk.WithServerURL(ServerURL),
+		serverOption,
 		sdk.WithConfigProvider(config),
 		sdk.WithClient(httpClient),
 	}
@@ -170,18 +303,34 @@ func (p *XshieldProvider) Configure(ctx context.Context, req provider.ConfigureR
 	client := sdk.New(opts...)
 
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &resourceData{
+		client: client,
+		fields: newTenantFields(client),
+		assets: newAssetSnapshot(client),
+	}
+}
+
+// resourceData is passed to the resources by Configure. Its caches live as long as the provider
+// instance and are shared by the resources it configures.
+type resourceData struct {
+	client *sdk.Xshield
+	fields *tenantFields
+	assets *assetSnapshot
 }
 
 // newHTTPClientWithOptions creates a custom HTTP client with proxy and timeout configuration
 func newHTTPClientWithOptions(data XshieldProviderModel) (*http.Client, error) {
 
-	// Create transport
+	tlsConfig, err := newTLSConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create transport. The TLS configuration is used for the connection to the server and
+	// for the connection to an https:// proxy alike.
 	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{
-			MinVersion: tls.VersionTLS12,
-		},
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
 	}
 
 	// Configure proxy if specified
@@ -215,37 +364,183 @@ func newHTTPClientWithOptions(data XshieldProviderModel) (*http.Client, error) {
 		transport.Proxy = http.ProxyURL(proxyURL)
 	}
 
+	var roundTripper http.RoundTripper = transport
+	if !data.RequestsPerSecond.IsNull() && !data.RequestsPerSecond.IsUnknown() {
+		if data.RequestsPerSecond.ValueFloat64() <= 0 {
+			return nil, fmt.Errorf("requests_per_second must be greater than 0")
+		}
+		roundTripper = &rateLimitedTransport{
+			limiter:   newTokenBucket(data.RequestsPerSecond.ValueFloat64()),
+			transport: transport,
+		}
+	}
+
+	// Resource timeouts report the last request sent when they expire
+	roundTripper = &operationStepTransport{transport: roundTripper}
+
 	// Create client with timeout
 	client := &http.Client{
-		Transport: transport,
+		Transport: roundTripper,
 		Timeout:   60 * time.Second,
 	}
 
+	// Log requests and responses, without credentials, when debug logging is on
+	if debugLoggingEnabled() {
+		maxBodyLength := defaultLogBodyLimit
+		if !data.LogBodyLimit.IsNull() && !data.LogBodyLimit.IsUnknown() {
+			maxBodyLength = int(data.LogBodyLimit.ValueInt64())
+		}
+		client.Transport = NewProviderHTTPTransport(ProviderHTTPTransportOpts{
+			Transport:     roundTripper,
+			MaxBodyLength: maxBodyLength,
+			Redact:        proxySecrets(data.ProxyCreds.ValueString()),
+		})
+	}
+
 	return client, nil
 }
 
-func buildConfigProvider(data XshieldProviderModel, resp *provider.ConfigureResponse) shared.ConfigurationProvider {
+// debugLoggingEnabled reports whether Terraform writes provider logs at the DEBUG level or below
+func debugLoggingEnabled() bool {
+	for _, envVar := range []string{"TF_LOG_PROVIDER", "TF_LOG"} {
+		switch strings.ToUpper(strings.TrimSpace(os.Getenv(envVar))) {
+		case "DEBUG", "TRACE", "JSON":
+			return true
+		case "":
+			continue
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// proxySecrets returns the forms in which proxy credentials may appear in a request
+func proxySecrets(credentials string) []string {
+	if credentials == "" {
+		return nil
+	}
+	secrets := []string{credentials, base64.StdEncoding.EncodeToString([]byte(credentials))}
+	if _, password, ok := strings.Cut(credentials, ":"); ok && password != "" {
+		secrets = append(secrets, password, url.QueryEscape(password))
+	}
+	return secrets
+}
+
+func buildConfigProvider(ctx context.Context, data XshieldProviderModel, profile *shared.FileConfigProvider, resp *provider.ConfigureResponse) shared.ConfigurationProvider {
+	if data.CredentialProcess.ValueString() != "" {
+		return buildCredentialProcessProvider(data, resp)
+	}
+
 	if data.TenancyId.ValueString() == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("provider").AtName("tenancy_id"), "required attribute is missing or empty", "")
+		missingAttributeError(&resp.Diagnostics, "tenancy_id", settingSources("tenancy_id", envTenancyID, shared.ProfileKeyTenancy, profile)...)
 	}
 
 	if data.PrincipalId.ValueString() == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("provider").AtName("user_id"), "required attribute is missing or empty", "")
+		missingAttributeError(&resp.Diagnostics, "user_id", settingSources("user_id", envUserID, shared.ProfileKeyUser, profile)...)
 	}
 
 	if data.FingerPrint.ValueString() == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("provider").AtName("fingerprint"), "required attribute is missing or empty", "")
+		missingAttributeError(&resp.Diagnostics, "fingerprint", settingSources("fingerprint", envFingerprint, shared.ProfileKeyFingerprint, profile)...)
 	}
 
-	if data.PrivateKeyLocation.ValueString() == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("provider").AtName("private_key_location"), "required attribute is missing or empty", "")
+	if data.PrivateKeyLocation.ValueString() == "" && data.PrivateKey.ValueString() == "" {
+		sources := append(settingSources("private_key_path", envPrivateKeyPath, shared.ProfileKeyKeyFile, profile), settingSources("private_key", envPrivateKey, "", nil)...)
+		missingAttributeError(&resp.Diagnostics, "private_key_path", sources...)
 	}
 
 	if resp.Diagnostics.HasError() {
 		return nil
 	}
 
-	return shared.NewRawConfigProvider(data.TenancyId.ValueString(), data.PrincipalId.ValueString(), data.FingerPrint.ValueString(), data.PrivateKeyLocation.ValueString())
+	var loader shared.PrivateKeyLoader
+	keyAttribute := "private_key_path"
+	if data.PrivateKey.ValueString() != "" {
+		loader = shared.NewPrivateKeyFromByteSliceWithPassphrase(data.PrivateKey.ValueString(), data.PrivateKeyPass.ValueString())
+		keyAttribute = "private_key"
+	} else {
+		loader = shared.NewCachingPrivateKeyFromFileLoader(ctx, data.PrivateKeyLocation.ValueString(), data.PrivateKeyPass.ValueString())
+	}
+
+	config := shared.NewConfigProvider(data.TenancyId.ValueString(), data.PrincipalId.ValueString(), data.FingerPrint.ValueString(), loader)
+	verifySigningMaterial(config, keyAttribute, "fingerprint", resp)
+
+	return config
+}
+
+// verifySigningMaterial loads the private key and checks that it matches the fingerprint,
+// so that an unusable key or a wrong fingerprint is reported before the API answers 401
+func verifySigningMaterial(config shared.ConfigurationProvider, keyAttribute string, fingerprintAttribute string, resp *provider.ConfigureResponse) {
+	material, err := shared.LoadSigningMaterial(config)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(keyAttribute), "unable to load private key", err.Error())
+		return
+	}
+
+	fingerprint, err := shared.KeyFingerprint(&material.Key.PublicKey)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(keyAttribute), "unable to load private key", err.Error())
+		return
+	}
+
+	if !shared.FingerprintsEqual(fingerprint, material.FingerPrint) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(fingerprintAttribute),
+			"fingerprint does not match the private key",
+			fmt.Sprintf("The configured fingerprint is %q but the private key has fingerprint %q. Check that the fingerprint and the private key belong to the same API key.", material.FingerPrint, fingerprint),
+		)
+	}
+}
+
+// resolveServer selects the server from server_url, or else from region, defaulting to the
+// US region
+func resolveServer(ctx context.Context, data XshieldProviderModel, diags *diag.Diagnostics) sdk.SDKOption {
+	if serverURL := data.ServerURL.ValueString(); serverURL != "" {
+		tflog.Info(ctx, "Using configured server", map[string]interface{}{"server_url": serverURL})
+		return sdk.WithServerURL(serverURL)
+	}
+
+	region := data.Region.ValueString()
+	if region == "" {
+		region = sdk.ServerIad
+	}
+	serverURL, ok := sdk.ServerList[region]
+	if !ok {
+		diags.AddAttributeError(
+			path.Root("region"),
+			"invalid region",
+			fmt.Sprintf("Region %q is not known, expected one of: %s. Checked the region provider attribute, the %s environment variable and the region key of the profile.", region, strings.Join(serverNames(), ", "), envRegion),
+		)
+		return nil
+	}
+
+	tflog.Info(ctx, "Using region server", map[string]interface{}{"region": region, "server_url": serverURL})
+	return sdk.WithServer(region)
+}
+
+// serverNames returns the names of the regions known to the SDK, sorted
+func serverNames() []string {
+	names := make([]string, 0, len(sdk.ServerList))
+	for name := range sdk.ServerList {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// buildCredentialProcessProvider runs the credential process once so that a broken command
+// is reported before any resource is touched
+func buildCredentialProcessProvider(data XshieldProviderModel, resp *provider.ConfigureResponse) shared.ConfigurationProvider {
+	config, err := shared.NewCredentialProcessProvider(data.CredentialProcess.ValueString())
+	if err == nil {
+		err = config.Refresh()
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("credential_process"), "unable to obtain credentials", err.Error())
+		return nil
+	}
+	verifySigningMaterial(config, "credential_process", "credential_process", resp)
+	return config
 }
 
 func (p *XshieldProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -261,10 +556,32 @@ func (p *XshieldProvider) Resources(ctx context.Context) []func() resource.Resou
 func (p *XshieldProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAssetDataSource,
+		NewAssetIdentitiesDataSource,
+		NewAssetSecurityPatchesDataSource,
+		NewAssetTagsDataSource,
+		NewAssetVulnerabilitiesDataSource,
+		NewAssetVulnerablePackagesDataSource,
+		NewMetadataFieldsDataSource,
+		NewMetricsDataSource,
 		NewNamedNetworkDataSource,
+		NewNamedNetworkHitsDataSource,
+		NewNetworkMapDataSource,
 		NewSegmentDataSource,
 		NewTagRuleDataSource,
+		NewTagsDataSource,
 		NewTemplateDataSource,
+		NewUserGroupHitsDataSource,
+	}
+}
+
+func (p *XshieldProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewCIDRMergeFunction,
+		NewCIDRNormalizeFunction,
+		NewCriteriaFunction,
+		NewCriteriaAndFunction,
+		NewIPRangeToCIDRsFunction,
+		NewQuoteFunction,
 	}
 }
 
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Environment variables read when the matching provider attribute is not set
+const (
+	envServerURL         = "XSHIELD_SERVER_URL"
+	envRegion            = "XSHIELD_REGION"
+	envTenancyID         = "XSHIELD_TENANCY_ID"
+	envUserID            = "XSHIELD_USER_ID"
+	envFingerprint       = "XSHIELD_FINGERPRINT"
+	envPrivateKeyPath    = "XSHIELD_PRIVATE_KEY_PATH"
+	envPrivateKey        = "XSHIELD_PRIVATE_KEY"
+	envPrivateKeyPass    = "XSHIELD_PRIVATE_KEY_PASSPHRASE"
+	envCredentialProcess = "XSHIELD_CREDENTIAL_PROCESS"
+	envProxyURL          = "XSHIELD_PROXY_URL"
+	envProxyCreds        = "XSHIELD_PROXY_CREDS"
+	envCACertFile        = "XSHIELD_CA_CERT_FILE"
+	envCACertPEM         = "XSHIELD_CA_CERT_PEM"
+	envClientCertFile    = "XSHIELD_CLIENT_CERT_FILE"
+	envClientCertPEM     = "XSHIELD_CLIENT_CERT_PEM"
+	envClientKeyFile     = "XSHIELD_CLIENT_KEY_FILE"
+	envClientKeyPEM      = "XSHIELD_CLIENT_KEY_PEM"
+	envInsecureSkipTLS   = "XSHIELD_INSECURE_SKIP_VERIFY"
+	envLogBodyLimit      = "XSHIELD_LOG_BODY_LIMIT"
+	envRequestsPerSecond = "XSHIELD_REQUESTS_PER_SECOND"
+	envRequestTimeout    = "XSHIELD_REQUEST_TIMEOUT"
+	envInitialInterval   = "XSHIELD_INITIAL_INTERVAL"
+	envMaxInterval       = "XSHIELD_MAX_INTERVAL"
+	envMaxElapsedTime    = "XSHIELD_MAX_ELAPSED_TIME"
+	envExponent          = "XSHIELD_EXPONENT"
+)
+
+// applyEnvironment fills every provider attribute left unset in the configuration from its
+// environment variable. Values set in the configuration always win.
+func applyEnvironment(data *XshieldProviderModel, diags *diag.Diagnostics) {
+	// A server chosen in the configuration, by URL or by region, is not overridden
+	if isUnset(data.ServerURL) && isUnset(data.Region) {
+		data.ServerURL = stringFromEnv(data.ServerURL, envServerURL)
+		data.Region = stringFromEnv(data.Region, envRegion)
+		if !isUnset(data.ServerURL) && !isUnset(data.Region) {
+			diags.AddAttributeError(path.Root("region"), "conflicting environment variables", fmt.Sprintf("%s and %s are both set. Set only one of them.", envServerURL, envRegion))
+		}
+	}
+	data.TenancyId = stringFromEnv(data.TenancyId, envTenancyID)
+	data.PrincipalId = stringFromEnv(data.PrincipalId, envUserID)
+	data.FingerPrint = stringFromEnv(data.FingerPrint, envFingerprint)
+	// Key material from the environment must not override a key set in the configuration
+	// through the other attribute
+	if isUnset(data.PrivateKeyLocation) && isUnset(data.PrivateKey) && isUnset(data.CredentialProcess) {
+		data.PrivateKeyLocation = stringFromEnv(data.PrivateKeyLocation, envPrivateKeyPath)
+		data.PrivateKey = stringFromEnv(data.PrivateKey, envPrivateKey)
+		data.CredentialProcess = stringFromEnv(data.CredentialProcess, envCredentialProcess)
+
+		var set []string
+		for envVar, value := range map[string]types.String{envPrivateKeyPath: data.PrivateKeyLocation, envPrivateKey: data.PrivateKey, envCredentialProcess: data.CredentialProcess} {
+			if !isUnset(value) {
+				set = append(set, envVar)
+			}
+		}
+		if len(set) > 1 {
+			sort.Strings(set)
+			diags.AddAttributeError(path.Root("private_key_path"), "conflicting environment variables", fmt.Sprintf("%s are set. Set only one of them.", strings.Join(set, " and ")))
+		}
+	}
+	data.PrivateKeyPass = stringFromEnv(data.PrivateKeyPass, envPrivateKeyPass)
+	// Multi-line values are often flattened with literal \n sequences in CI secret stores
+	if v := data.PrivateKey.ValueString(); v != "" && !strings.Contains(v, "\n") {
+		data.PrivateKey = types.StringValue(strings.ReplaceAll(v, `\n`, "\n"))
+	}
+	data.ProxyURL = stringFromEnv(data.ProxyURL, envProxyURL)
+	data.ProxyCreds = stringFromEnv(data.ProxyCreds, envProxyCreds)
+	if isUnset(data.CACertFile) && isUnset(data.CACertPEM) {
+		data.CACertFile = stringFromEnv(data.CACertFile, envCACertFile)
+		data.CACertPEM = stringFromEnv(data.CACertPEM, envCACertPEM)
+	}
+	if isUnset(data.ClientCertFile) && isUnset(data.ClientCertPEM) {
+		data.ClientCertFile = stringFromEnv(data.ClientCertFile, envClientCertFile)
+		data.ClientCertPEM = stringFromEnv(data.ClientCertPEM, envClientCertPEM)
+	}
+	if isUnset(data.ClientKeyFile) && isUnset(data.ClientKeyPEM) {
+		data.ClientKeyFile = stringFromEnv(data.ClientKeyFile, envClientKeyFile)
+		data.ClientKeyPEM = stringFromEnv(data.ClientKeyPEM, envClientKeyPEM)
+	}
+	data.InsecureSkipVerify = boolFromEnv(data.InsecureSkipVerify, envInsecureSkipTLS, "insecure_skip_verify", diags)
+	data.RequestTimeout = int64FromEnv(data.RequestTimeout, envRequestTimeout, "request_timeout", diags)
+	data.InitialInterval = int64FromEnv(data.InitialInterval, envInitialInterval, "initial_interval", diags)
+	data.MaxInterval = int64FromEnv(data.MaxInterval, envMaxInterval, "max_interval", diags)
+	data.MaxElapsedTime = int64FromEnv(data.MaxElapsedTime, envMaxElapsedTime, "max_elapsed_time", diags)
+	data.RequestsPerSecond = float64FromEnv(data.RequestsPerSecond, envRequestsPerSecond, "requests_per_second", diags)
+	data.LogBodyLimit = int64FromEnv(data.LogBodyLimit, envLogBodyLimit, "log_body_limit", diags)
+	data.Exponent = float64FromEnv(data.Exponent, envExponent, "exponent", diags)
+}
+
+func isUnset(value types.String) bool {
+	return value.IsNull() || value.IsUnknown() || value.ValueString() == ""
+}
+
+func stringFromEnv(value types.String, envVar string) types.String {
+	if !isUnset(value) {
+		return value
+	}
+	if v := strings.TrimSpace(os.Getenv(envVar)); v != "" {
+		return types.StringValue(v)
+	}
+	return value
+}
+
+func int64FromEnv(value types.Int64, envVar string, attribute string, diags *diag.Diagnostics) types.Int64 {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value
+	}
+	v := strings.TrimSpace(os.Getenv(envVar))
+	if v == "" {
+		return value
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		diags.AddAttributeError(path.Root(attribute), "invalid environment variable", fmt.Sprintf("%s must be an integer: %s", envVar, err))
+		return value
+	}
+	return types.Int64Value(n)
+}
+
+func boolFromEnv(value types.Bool, envVar string, attribute string, diags *diag.Diagnostics) types.Bool {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value
+	}
+	v := strings.TrimSpace(os.Getenv(envVar))
+	if v == "" {
+		return value
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		diags.AddAttributeError(path.Root(attribute), "invalid environment variable", fmt.Sprintf("%s must be true or false: %s", envVar, err))
+		return value
+	}
+	return types.BoolValue(b)
+}
+
+func float64FromEnv(value types.Float64, envVar string, attribute string, diags *diag.Diagnostics) types.Float64 {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value
+	}
+	v := strings.TrimSpace(os.Getenv(envVar))
+	if v == "" {
+		return value
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		diags.AddAttributeError(path.Root(attribute), "invalid environment variable", fmt.Sprintf("%s must be a number: %s", envVar, err))
+		return value
+	}
+	return types.Float64Value(n)
+}
+
+// missingAttributeError reports a required setting found in none of the sources checked
+func missingAttributeError(diags *diag.Diagnostics, attribute string, sources ...string) {
+	checked := strings.Join(sources, ", ")
+	if len(sources) > 1 {
+		checked = strings.Join(sources[:len(sources)-1], ", ") + " and " + sources[len(sources)-1]
+	}
+	diags.AddAttributeError(
+		path.Root(attribute),
+		"required attribute is missing or empty",
+		fmt.Sprintf("Checked %s. Set one of them.", checked),
+	)
+}
+
+// settingSources describes where a setting is looked up, in order of precedence
+func settingSources(attribute string, envVar string, profileKey string, profile *shared.FileConfigProvider) []string {
+	sources := []string{
+		fmt.Sprintf("the %s provider attribute", attribute),
+		fmt.Sprintf("the %s environment variable", envVar),
+	}
+	if profile != nil {
+		sources = append(sources, fmt.Sprintf("the %s key of %s", profileKey, profile))
+	}
+	return sources
+}
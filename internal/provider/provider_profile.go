@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Environment variables selecting the configuration file profile
+const (
+	envProfile    = "XSHIELD_PROFILE"
+	envConfigFile = "XSHIELD_CONFIG_FILE"
+)
+
+// loadProfile reads the profile named by the profile attribute or XSHIELD_PROFILE. When no
+// profile is named, the DEFAULT profile is read if the configuration file exists. It
+// returns nil when there is no profile to read.
+func loadProfile(data XshieldProviderModel, diags *diag.Diagnostics) *shared.FileConfigProvider {
+	name := stringFromEnv(data.Profile, envProfile).ValueString()
+
+	configFile := strings.TrimSpace(os.Getenv(envConfigFile))
+	if configFile == "" {
+		var err error
+		if configFile, err = shared.DefaultConfigFilePath(); err != nil {
+			if name != "" {
+				diags.AddAttributeError(path.Root("profile"), "unable to read profile", err.Error())
+			}
+			return nil
+		}
+	}
+
+	if name == "" {
+		if _, err := os.Stat(configFile); errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+	}
+
+	profile, err := shared.NewFileConfigProvider(configFile, name)
+	if name == "" && errors.Is(err, shared.ErrProfileNotFound) {
+		return nil
+	}
+	if err != nil {
+		diags.AddAttributeError(path.Root("profile"), "unable to read profile", err.Error())
+		return nil
+	}
+	return &profile
+}
+
+// applyProfile fills every setting still unset after the configuration and environment
+// from profile
+func applyProfile(data *XshieldProviderModel, profile *shared.FileConfigProvider, diags *diag.Diagnostics) {
+	if profile == nil {
+		return
+	}
+
+	data.TenancyId = stringFromProfile(data.TenancyId, profile.TenancyId())
+	data.PrincipalId = stringFromProfile(data.PrincipalId, profile.PrincipalId())
+	data.FingerPrint = stringFromProfile(data.FingerPrint, profile.FingerPrint())
+	if isUnset(data.PrivateKeyLocation) && isUnset(data.PrivateKey) && isUnset(data.CredentialProcess) {
+		data.PrivateKeyLocation = stringFromProfile(data.PrivateKeyLocation, profile.KeyFile())
+		data.PrivateKeyPass = stringFromProfile(data.PrivateKeyPass, profile.Value(shared.ProfileKeyPassPhrase))
+		data.CredentialProcess = stringFromProfile(data.CredentialProcess, profile.Value(shared.ProfileKeyCredentialProcess))
+	}
+	data.ProxyURL = stringFromProfile(data.ProxyURL, profile.Value(shared.ProfileKeyProxy))
+	data.ProxyCreds = stringFromProfile(data.ProxyCreds, profile.Value(shared.ProfileKeyProxyCreds))
+
+	if isUnset(data.ServerURL) && isUnset(data.Region) {
+		data.ServerURL = stringFromProfile(data.ServerURL, profile.Value(shared.ProfileKeyServerURL))
+		data.Region = stringFromProfile(data.Region, profile.Value(shared.ProfileKeyRegion))
+		if !isUnset(data.ServerURL) && !isUnset(data.Region) {
+			diags.AddAttributeError(path.Root("profile"), "conflicting profile settings", fmt.Sprintf("The %s and %s keys of %s are both set. Set only one of them.", shared.ProfileKeyServerURL, shared.ProfileKeyRegion, profile))
+		}
+	}
+}
+
+func stringFromProfile(value types.String, profileValue string) types.String {
+	if !isUnset(value) || profileValue == "" {
+		return value
+	}
+	return types.StringValue(profileValue)
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenBucket limits the rate of events to rate per second with bursts of up to burst
+// events
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := math.Max(1, math.Ceil(rate))
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until an event is allowed or ctx is done
+func (tb *tokenBucket) Wait(ctx context.Context) error {
+	delay := tb.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// Give the token back to the requests still waiting
+		tb.mu.Lock()
+		tb.tokens = math.Min(tb.burst, tb.tokens+1)
+		tb.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token and returns how long to wait until it is available. Tokens may go
+// negative so that waiting requests are served in order.
+func (tb *tokenBucket) reserve(now time.Time) time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if elapsed := now.Sub(tb.last); elapsed > 0 {
+		tb.tokens = math.Min(tb.burst, tb.tokens+elapsed.Seconds()*tb.rate)
+		tb.last = now
+	}
+	tb.tokens--
+	if tb.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+}
+
+// rateLimitedTransport holds every request, retries included, until the token bucket
+// allows it. The provider shares one HTTP client, and so one bucket, between all resources
+// and data sources.
+type rateLimitedTransport struct {
+	limiter   *tokenBucket
+	transport http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.transport.RoundTrip(req)
+}
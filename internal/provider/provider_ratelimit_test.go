@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	tb := newTokenBucket(2)
+	now := tb.last
+
+	// The burst of two is available at once, the next tokens every half second, in order
+	for i, want := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+		if got := tb.reserve(now); got != want {
+			t.Errorf("reserve %d waits %s, want %s", i, got, want)
+		}
+	}
+
+	// Two seconds later the queue is served and one token is back
+	if got := tb.reserve(now.Add(2 * time.Second)); got != 0 {
+		t.Errorf("reserve after refill waits %s, want 0", got)
+	}
+}
+
+func TestTokenBucketWaitBlocks(t *testing.T) {
+	tb := newTokenBucket(10)
+	for i := 0; i < 10; i++ {
+		if err := tb.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now()
+	if err := tb.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("request past the burst waited %s, want about 100ms", elapsed)
+	}
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	tb := newTokenBucket(0.1)
+	if err := tb.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := tb.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want the deadline of the context", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("cancelled wait returned after %s", elapsed)
+	}
+
+	// The cancelled request gave its token back, so the next one waits a single interval
+	if delay := tb.reserve(time.Now()); delay > 10*time.Second {
+		t.Errorf("next request waits %s, want at most 10s", delay)
+	}
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: &rateLimitedTransport{limiter: newTokenBucket(0.1), transport: http.DefaultTransport}}
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	// The second request would wait ten seconds, past the deadline of its context
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want the deadline of the context", err)
+	}
+	if requests != 1 {
+		t.Errorf("server received %d requests, want only the first", requests)
+	}
+}
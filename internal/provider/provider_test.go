@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestVerifySigningMaterial(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	fingerprint, err := shared.KeyFingerprint(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		fingerprint string
+		keyPEM      string
+		wantPath    path.Path
+	}{
+		{name: "matching", fingerprint: fingerprint, keyPEM: keyPEM},
+		{name: "other fingerprint", fingerprint: "00:11:22:33:44:55:66:77:88:99:aa:bb:cc:dd:ee:ff", keyPEM: keyPEM, wantPath: path.Root("fingerprint")},
+		{name: "unusable key", fingerprint: fingerprint, keyPEM: "not a key", wantPath: path.Root("private_key")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := shared.NewDirectConfigProvider("tenant", "user", test.fingerprint, test.keyPEM)
+			var resp provider.ConfigureResponse
+			verifySigningMaterial(config, "private_key", "fingerprint", &resp)
+
+			if len(test.wantPath.Steps()) == 0 {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if len(resp.Diagnostics) != 1 {
+				t.Fatalf("got diagnostics %v, want one error", resp.Diagnostics)
+			}
+			assertDiagnosticPath(t, resp.Diagnostics[0], test.wantPath)
+		})
+	}
+}
+
+// doerFunc is an sdk.HTTPClient calling a function instead of a server
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// requestedServer returns the scheme and host a client configured with option sends requests to
+func requestedServer(t *testing.T, option sdk.SDKOption) string {
+	t.Helper()
+
+	var server string
+	client := sdk.New(
+		option,
+		sdk.WithClient(doerFunc(func(req *http.Request) (*http.Response, error) {
+			server = req.URL.Scheme + "://" + req.URL.Host
+			return nil, errors.New("not sent")
+		})),
+		sdk.WithConfigProvider(shared.NewDirectConfigProvider("tenant", "user", "fingerprint", testKeyPEM(t))),
+	)
+	_, _ = client.Metadata.ListFields(context.Background(), operations.ListFieldsRequest{Scope: operations.ScopeAsset.ToPointer()})
+	return server
+}
+
+func TestResolveServer(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverURL  string
+		region     string
+		wantServer string
+	}{
+		{name: "default region", wantServer: "https://ng.colortokens.com"},
+		{name: "iad", region: "iad", wantServer: "https://ng.colortokens.com"},
+		{name: "bom", region: "bom", wantServer: "https://bom.colortokens.com"},
+		{name: "fra", region: "fra", wantServer: "https://fra.colortokens.com"},
+		{name: "syd", region: "syd", wantServer: "https://syd.colortokens.com"},
+		{name: "server url", serverURL: "https://xshield.example.com", wantServer: "https://xshield.example.com"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := XshieldProviderModel{ServerURL: types.StringValue(test.serverURL), Region: types.StringValue(test.region)}
+			var diags diag.Diagnostics
+			option := resolveServer(context.Background(), data, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got := requestedServer(t, option); got != test.wantServer {
+				t.Errorf("requests sent to %s, want %s", got, test.wantServer)
+			}
+		})
+	}
+}
+
+func TestResolveServerUnknownRegion(t *testing.T) {
+	data := XshieldProviderModel{Region: types.StringValue("mars")}
+	var diags diag.Diagnostics
+	if option := resolveServer(context.Background(), data, &diags); option != nil {
+		t.Error("got a server option for an unknown region")
+	}
+	if len(diags) != 1 {
+		t.Fatalf("got diagnostics %v, want one error", diags)
+	}
+	assertDiagnosticPath(t, diags[0], path.Root("region"))
+}
+
+func TestRegionConflictsWithServerURL(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp provider.SchemaResponse
+	(&XshieldProvider{}).Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: objectWith(objectType, map[string]tftypes.Value{
+		"server_url": tftypes.NewValue(tftypes.String, "https://xshield.example.com"),
+		"region":     tftypes.NewValue(tftypes.String, "fra"),
+	})}
+
+	req := validator.StringRequest{Path: path.Root("region"), PathExpression: path.MatchRoot("region"), Config: config, ConfigValue: types.StringValue("fra")}
+	var diags diag.Diagnostics
+	for _, v := range schemaResp.Schema.Attributes["region"].(schema.StringAttribute).Validators {
+		var resp validator.StringResponse
+		v.ValidateString(ctx, req, &resp)
+		diags.Append(resp.Diagnostics...)
+	}
+	if !diags.HasError() {
+		t.Fatal("region and server_url both set without an error")
+	}
+	assertDiagnosticPath(t, diags.Errors()[0], path.Root("region"))
+}
+
+func TestBuildCredentialProcessProviderFailure(t *testing.T) {
+	data := XshieldProviderModel{CredentialProcess: types.StringValue("exit 1")}
+	var resp provider.ConfigureResponse
+	if config := buildCredentialProcessProvider(data, &resp); config != nil {
+		t.Error("got a configuration from a failing credential process")
+	}
+	if len(resp.Diagnostics) != 1 {
+		t.Fatalf("got diagnostics %v, want one error", resp.Diagnostics)
+	}
+	assertDiagnosticPath(t, resp.Diagnostics[0], path.Root("credential_process"))
+}
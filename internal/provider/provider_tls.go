@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// newTLSConfig builds the TLS configuration of the HTTP client from the ca_cert_*,
+// client_cert_*, client_key_* and insecure_skip_verify settings
+func newTLSConfig(data XshieldProviderModel) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	caPEM, err := pemSetting(data.CACertPEM.ValueString(), data.CACertFile.ValueString(), "ca_cert_file")
+	if err != nil {
+		return nil, err
+	}
+	if len(caPEM) > 0 {
+		// Custom CAs are trusted in addition to the system roots so that a proxy CA does not
+		// break verification of the public endpoints
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in ca_cert_file or ca_cert_pem")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	certPEM, err := pemSetting(data.ClientCertPEM.ValueString(), data.ClientCertFile.ValueString(), "client_cert_file")
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := pemSetting(data.ClientKeyPEM.ValueString(), data.ClientKeyFile.ValueString(), "client_key_file")
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(certPEM) > 0 && len(keyPEM) > 0:
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	case len(certPEM) > 0:
+		return nil, fmt.Errorf("client certificate is set without client_key_file or client_key_pem")
+	case len(keyPEM) > 0:
+		return nil, fmt.Errorf("client key is set without client_cert_file or client_cert_pem")
+	}
+
+	if data.InsecureSkipVerify.ValueBool() {
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // explicitly requested for lab environments
+	}
+
+	return tlsConfig, nil
+}
+
+// pemSetting returns the inline PEM value, or the content of the file when only the file
+// location is set
+func pemSetting(inline string, file string, fileAttribute string) ([]byte, error) {
+	if inline != "" {
+		return []byte(inline), nil
+	}
+	if file == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s. Path: %s, Error: %v", fileAttribute, file, err)
+	}
+	return content, nil
+}
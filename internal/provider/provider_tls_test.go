@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// getWithTLSConfig sends a GET request to url with the TLS configuration built from data
+func getWithTLSConfig(t *testing.T, data XshieldProviderModel, url string) error {
+	t.Helper()
+
+	tlsConfig, err := newTLSConfig(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	defer client.CloseIdleConnections()
+
+	res, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func certificatePEM(certificate *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}))
+}
+
+// newClientCertificate returns a self-signed client certificate and its key, PEM encoded
+func newClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+	return certificate, certificatePEM(certificate), keyPEM
+}
+
+func TestNewTLSConfigCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	if err := getWithTLSConfig(t, XshieldProviderModel{}, server.URL); err == nil {
+		t.Fatal("trusted the test server without its CA")
+	}
+
+	t.Run("ca_cert_pem", func(t *testing.T) {
+		data := XshieldProviderModel{CACertPEM: types.StringValue(certificatePEM(server.Certificate()))}
+		if err := getWithTLSConfig(t, data, server.URL); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("ca_cert_file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "ca.pem")
+		if err := os.WriteFile(file, []byte(certificatePEM(server.Certificate())), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		data := XshieldProviderModel{CACertFile: types.StringValue(file)}
+		if err := getWithTLSConfig(t, data, server.URL); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestNewTLSConfigClientCertificate(t *testing.T) {
+	clientCertificate, certPEM, keyPEM := newClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCertificate)
+
+	var gotCommonName string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCommonName = r.TLS.PeerCertificates[0].Subject.CommonName
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	caPEM := types.StringValue(certificatePEM(server.Certificate()))
+
+	if err := getWithTLSConfig(t, XshieldProviderModel{CACertPEM: caPEM}, server.URL); err == nil {
+		t.Fatal("the server accepted a request without client certificate")
+	}
+
+	data := XshieldProviderModel{
+		CACertPEM:     caPEM,
+		ClientCertPEM: types.StringValue(certPEM),
+		ClientKeyPEM:  types.StringValue(keyPEM),
+	}
+	if err := getWithTLSConfig(t, data, server.URL); err != nil {
+		t.Fatal(err)
+	}
+	if gotCommonName != "terraform" {
+		t.Errorf("server saw client certificate %q, want %q", gotCommonName, "terraform")
+	}
+}
+
+func TestNewTLSConfigClientCertificateWithoutKey(t *testing.T) {
+	_, certPEM, _ := newClientCertificate(t)
+
+	if _, err := newTLSConfig(XshieldProviderModel{ClientCertPEM: types.StringValue(certPEM)}); err == nil {
+		t.Fatal("accepted a client certificate without key")
+	}
+}
+
+func TestNewTLSConfigInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	data := XshieldProviderModel{InsecureSkipVerify: types.BoolValue(true)}
+	if err := getWithTLSConfig(t, data, server.URL); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// Operations configurable in the timeouts block of a resource
+const (
+	timeoutCreate = "create"
+	timeoutRead   = "read"
+	timeoutUpdate = "update"
+	timeoutDelete = "delete"
+)
+
+// timeoutsBlock returns the timeouts block shared by all resources
+func timeoutsBlock(ctx context.Context) schema.Block {
+	description := func(operation string) string {
+		return fmt.Sprintf("Time allowed for the whole %s, every API request and retry included, as a duration such as `30s` or `20m`. Not limited by default.", operation)
+	}
+	block := timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: description(timeoutCreate),
+		ReadDescription:   description(timeoutRead),
+		UpdateDescription: description(timeoutUpdate),
+		DeleteDescription: description(timeoutDelete),
+	})
+	if nested, ok := block.(schema.SingleNestedBlock); ok {
+		nested.MarkdownDescription = "Deadlines of the resource operations. Each one bounds the complete operation, which may take several API requests, in addition to the `request_timeout` of every request."
+		return nested
+	}
+	return block
+}
+
+// attributeGetter is implemented by tfsdk.Plan and tfsdk.State
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+type operationTimeoutKey struct{}
+
+// operationTimeout is the deadline of one resource operation. It records the step the
+// operation is at, so that a timeout can be reported with the step that did not finish.
+type operationTimeout struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	operation string
+	timeout   time.Duration
+
+	mu   sync.Mutex
+	step string
+}
+
+// withOperationTimeout applies the timeout configured for operation in the timeouts block
+// of source as a deadline of ctx. Call done with the diagnostics of the operation once it
+// returns.
+func withOperationTimeout(ctx context.Context, source attributeGetter, operation string, diags *diag.Diagnostics) (context.Context, *operationTimeout) {
+	var value timeouts.Value
+	diags.Append(source.GetAttribute(ctx, path.Root("timeouts"), &value)...)
+	if diags.HasError() {
+		return ctx, nil
+	}
+
+	// A zero default leaves the operation without deadline when no timeout is configured
+	var duration time.Duration
+	var timeoutDiags diag.Diagnostics
+	switch operation {
+	case timeoutCreate:
+		duration, timeoutDiags = value.Create(ctx, 0)
+	case timeoutRead:
+		duration, timeoutDiags = value.Read(ctx, 0)
+	case timeoutUpdate:
+		duration, timeoutDiags = value.Update(ctx, 0)
+	case timeoutDelete:
+		duration, timeoutDiags = value.Delete(ctx, 0)
+	}
+	diags.Append(timeoutDiags...)
+	if timeoutDiags.HasError() || duration <= 0 {
+		return ctx, nil
+	}
+
+	ot := &operationTimeout{operation: operation, timeout: duration}
+	ot.ctx, ot.cancel = context.WithTimeout(context.WithValue(ctx, operationTimeoutKey{}, ot), duration)
+	return ot.ctx, ot
+}
+
+// done releases the deadline and reports the step at which the operation timed out
+func (ot *operationTimeout) done(diags *diag.Diagnostics) {
+	if ot == nil {
+		return
+	}
+	defer ot.cancel()
+
+	if !errors.Is(ot.ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+
+	ot.mu.Lock()
+	step := ot.step
+	ot.mu.Unlock()
+	if step == "" {
+		step = "preparing the first request"
+	}
+
+	diags.AddError(
+		fmt.Sprintf("%s timed out", ot.operation),
+		fmt.Sprintf("The %s timeout of %s expired while %s. Increase timeouts.%s if the operation needs more time.", ot.operation, ot.timeout, step, ot.operation),
+	)
+}
+
+// setOperationStep records the step the operation of ctx is at, if it has a timeout
+func setOperationStep(ctx context.Context, step string) {
+	ot, ok := ctx.Value(operationTimeoutKey{}).(*operationTimeout)
+	if !ok {
+		return
+	}
+	ot.mu.Lock()
+	ot.step = step
+	ot.mu.Unlock()
+}
+
+// operationStepTransport records every SDK request as the current step of the resource
+// operation it is sent for
+type operationStepTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *operationStepTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if operationID := sdk.OperationID(req.Context()); operationID != "" {
+		setOperationStep(req.Context(), fmt.Sprintf("waiting for the %s request", operationID))
+	}
+	return t.transport.RoundTrip(req)
+}
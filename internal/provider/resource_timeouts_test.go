@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// timeoutsPlan returns a plan of a schema with only the timeouts block, create set to create
+func timeoutsPlan(ctx context.Context, create tftypes.Value) tfsdk.Plan {
+	s := schema.Schema{Blocks: map[string]schema.Block{"timeouts": timeoutsBlock(ctx)}}
+	timeoutsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		timeoutCreate: tftypes.String,
+		timeoutRead:   tftypes.String,
+		timeoutUpdate: tftypes.String,
+		timeoutDelete: tftypes.String,
+	}}
+	return tfsdk.Plan{
+		Schema: s,
+		Raw: tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"timeouts": timeoutsType}}, map[string]tftypes.Value{
+			"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+				timeoutCreate: create,
+				timeoutRead:   tftypes.NewValue(tftypes.String, nil),
+				timeoutUpdate: tftypes.NewValue(tftypes.String, nil),
+				timeoutDelete: tftypes.NewValue(tftypes.String, nil),
+			}),
+		}),
+	}
+}
+
+func TestWithOperationTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	timeoutCtx, timeout := withOperationTimeout(ctx, timeoutsPlan(ctx, tftypes.NewValue(tftypes.String, "20m")), timeoutCreate, &diags)
+	defer timeout.done(&diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	deadline, ok := timeoutCtx.Deadline()
+	if !ok {
+		t.Fatal("no deadline for a configured timeout")
+	}
+	if remaining := time.Until(deadline); remaining <= 19*time.Minute || remaining > 20*time.Minute {
+		t.Errorf("deadline in %s, want 20m", remaining)
+	}
+}
+
+func TestWithOperationTimeoutNotConfigured(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	timeoutCtx, timeout := withOperationTimeout(ctx, timeoutsPlan(ctx, tftypes.NewValue(tftypes.String, nil)), timeoutCreate, &diags)
+	defer timeout.done(&diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if _, ok := timeoutCtx.Deadline(); ok {
+		t.Error("deadline set without configured timeout")
+	}
+}
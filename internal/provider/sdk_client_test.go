@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+)
+
+// newTestClient returns a client of an API served by handler, signing requests with a
+// throwaway key
+func newTestClient(t *testing.T, handler http.Handler) *sdk.Xshield {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return sdk.New(
+		sdk.WithServerURL(server.URL),
+		sdk.WithClient(server.Client()),
+		sdk.WithConfigProvider(shared.NewDirectConfigProvider("tenant", "user", "fingerprint", testKeyPEM(t))),
+	)
+}
+
+// testKeyPEM returns a throwaway RSA private key encoded as PEM
+func testKeyPEM(t *testing.T) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+}
+
+// writeJSON answers a request of a test API with status and body encoded as JSON
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, body any) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		t.Error(err)
+	}
+}
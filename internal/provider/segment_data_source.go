@@ -4,10 +4,13 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
 	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -239,56 +242,37 @@ func (r *SegmentDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	} else if !data.TagBasedPolicyName.IsNull() && data.TagBasedPolicyName.ValueString() != "" {
 		// If we have a name, use ListTagBasedPolicies with a search criteria
 		policyName := data.TagBasedPolicyName.ValueString()
-		searchCriteria := fmt.Sprintf("tagBasedPolicyName = '%s'", policyName)
+		searchCriteria := criteria.Eq(criteria.Field("tagBasedPolicyName"), policyName).String()
 		listReq := operations.ListTagBasedPoliciesRequest{
 			SearchInput: shared.SearchInput{
 				Criteria: searchCriteria,
 			},
 		}
 
-		// Call the API
-		policies, err := r.client.Tagbasedpolicies.ListTagBasedPolicies(ctx, listReq)
-		if err != nil {
+		// Search every page of the results for the name
+		policy, found, listErr := r.client.Tagbasedpolicies.ListTagBasedPoliciesPager(listReq, sdk.DefaultPageSize).Find(ctx, func(policy shared.TagBasedPolicySummary) bool {
+			return policy.TagBasedPolicyName != nil && *policy.TagBasedPolicyName == policyName && policy.TagBasedPolicyID != nil
+		})
+		if listErr != nil {
 			resp.Diagnostics.AddError(
 				"Error retrieving segments",
-				fmt.Sprintf("Could not list segments to find by name: %s", err),
+				fmt.Sprintf("Could not list segments to find by name: %s", listErr),
 			)
 			return
 		}
-
-		// Process the response
-		if policies.TagBasedPolicies != nil && len(policies.TagBasedPolicies.Items) > 0 {
-			// Find the segment with the matching name
-			var foundID string
-			for _, policy := range policies.TagBasedPolicies.Items {
-				if policy.TagBasedPolicyName != nil && *policy.TagBasedPolicyName == policyName {
-					if policy.TagBasedPolicyID != nil {
-						foundID = *policy.TagBasedPolicyID
-						break
-					}
-				}
-			}
-
-			if foundID != "" {
-				// Now use GetTagBasedPolicy to get the full details
-				request := operations.GetTagBasedPolicyRequest{
-					TagbasedpolicyID: foundID,
-				}
-				res, err = r.client.Tagbasedpolicies.GetTagBasedPolicy(ctx, request)
-			} else {
-				resp.Diagnostics.AddError(
-					"Segment not found",
-					fmt.Sprintf("No segment found with name: %s", policyName),
-				)
-				return
-			}
-		} else {
+		if !found {
 			resp.Diagnostics.AddError(
 				"Segment not found",
-				fmt.Sprintf("No segments found matching the criteria: %s", searchCriteria),
+				fmt.Sprintf("No segment found with name: %s", policyName),
 			)
 			return
 		}
+
+		// Now use GetTagBasedPolicy to get the full details
+		request := operations.GetTagBasedPolicyRequest{
+			TagbasedpolicyID: *policy.TagBasedPolicyID,
+		}
+		res, err = r.client.Tagbasedpolicies.GetTagBasedPolicy(ctx, request)
 	} else {
 		// If we don't have either ID or name, return an error
 		resp.Diagnostics.AddError(
@@ -297,27 +281,25 @@ func (r *SegmentDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		)
 		return
 	}
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
 		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
 		return
 	}
-	if res.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.TagBasedPolicyResponse != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedTagBasedPolicyResponse(res.TagBasedPolicyResponse)
@@ -4,11 +4,18 @@ import (
 	"context"
 	"strings"
 
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// Tag key and value marking the assets managed by ColorTokens
+const (
+	managedByKey   = "managedby"
+	managedByValue = "colortokens"
+)
+
 // AppendManagedByModifier returns a plan modifier that appends the managedby condition to criteria
 func AppendManagedByModifier() planmodifier.String {
 	return &appendManagedByModifier{}
@@ -32,10 +39,11 @@ func (m *appendManagedByModifier) PlanModifyString(ctx context.Context, req plan
 
 	planValue := req.PlanValue.ValueString()
 
-	// Check if the managedby field is mentioned in any form
-	// Look for common patterns like 'managedby' = or 'managedby' in
-	if strings.Contains(strings.ToLower(planValue), "'managedby'") ||
-		strings.Contains(strings.ToLower(planValue), "\"managedby\"") {
+	// Check if the managedby key is tested by any condition, or if the criteria cannot be
+	// parsed, mentioned in any form like 'managedby' = or 'managedby' in
+	if parsed, err := criteria.Parse(planValue); (err == nil && criteria.HasKey(parsed, managedByKey)) ||
+		(err != nil && (strings.Contains(strings.ToLower(planValue), "'managedby'") ||
+			strings.Contains(strings.ToLower(planValue), "\"managedby\""))) {
 		// managedby is already specified in some form, honor it
 		tflog.Debug(ctx, "AppendManagedByModifier: managedby already specified, not modifying", map[string]interface{}{
 			"criteria": planValue,
@@ -44,7 +52,7 @@ func (m *appendManagedByModifier) PlanModifyString(ctx context.Context, req plan
 	}
 
 	// managedby not found, append the default condition
-	modifiedValue := planValue + " AND 'managedby' in ('colortokens')"
+	modifiedValue := criteria.AppendAnd(planValue, criteria.In(criteria.Tag(managedByKey), managedByValue))
 	tflog.Debug(ctx, "AppendManagedByModifier: Appending managedby condition", map[string]interface{}{
 		"original": planValue,
 		"modified": modifiedValue,
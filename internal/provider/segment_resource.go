@@ -4,6 +4,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -11,11 +12,14 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
 	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
 	speakeasy_objectvalidators "github.com/colortokens/terraform-provider-xshield/internal/validators/objectvalidators"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -32,6 +36,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SegmentResource{}
 var _ resource.ResourceWithImportState = &SegmentResource{}
+var _ resource.ResourceWithModifyPlan = &SegmentResource{}
 
 func NewSegmentResource() resource.Resource {
 	return &SegmentResource{}
@@ -40,6 +45,8 @@ func NewSegmentResource() resource.Resource {
 // SegmentResource defines the resource implementation.
 type SegmentResource struct {
 	client *sdk.Xshield
+	fields *tenantFields
+	assets *assetSnapshot
 }
 
 // SegmentResourceModel describes the resource data model.
@@ -63,6 +70,7 @@ type SegmentResourceModel struct {
 	TargetBreachImpactScore                types.Int64                             `tfsdk:"target_breach_impact_score"`
 	Templates                              []tfTypes.TemplateReference             `tfsdk:"templates"`
 	Timeline                               types.Int64                             `tfsdk:"timeline"`
+	Timeouts                               timeouts.Value                          `tfsdk:"timeouts"`
 }
 
 func (r *SegmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -86,6 +94,9 @@ func (r *SegmentResource) Schema(ctx context.Context, req resource.SchemaRequest
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.String{
+					criteriaSyntax(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Computed: true,
@@ -229,6 +240,9 @@ func (r *SegmentResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Description: `Timeline in days. Default: 90. Minimum: 1.`,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -238,21 +252,46 @@ func (r *SegmentResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.Xshield)
+	data, ok := req.ProviderData.(*resourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.fields = data.fields
+	r.assets = data.assets
+}
+
+// ModifyPlan warns about criteria keys and values that are not known to the tenant, and
+// previews the assets changed criteria will match
+func (r *SegmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the segment is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, state types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("criteria"), &config)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("criteria"), &state)...)
+	}
+	if resp.Diagnostics.HasError() || config.Equal(state) {
+		return
+	}
+	validateCriteriaFields(ctx, r.fields, path.Root("criteria"), config, &resp.Diagnostics)
+	previewCriteriaMatches(ctx, r.assets, path.Root("criteria"), state, config, &resp.Diagnostics)
 }
 
 func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.Plan, timeoutCreate, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *SegmentResourceModel
 	var plan types.Object
 
@@ -273,10 +312,7 @@ func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest
 	request := *data.ToSharedTagBasedPolicy()
 	res, err := r.client.Tagbasedpolicies.CreateTagBasedPolicy(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
@@ -284,11 +320,11 @@ func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 	if res.StatusCode != 202 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.TagBasedPolicyResponse != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 
@@ -415,10 +451,7 @@ func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest
 		// Call the automation configuration API
 		automationRes, err := r.client.Tagbasedpolicies.AutomationConfiguration(ctx, automationRequest)
 		if err != nil {
-			resp.Diagnostics.AddError("failure to invoke automation API", err.Error())
-			if automationRes != nil && automationRes.RawResponse != nil {
-				resp.Diagnostics.AddError("unexpected http request/response", debugResponse(automationRes.RawResponse))
-			}
+			addAPIError(ctx, &resp.Diagnostics, "failure to invoke automation API", err, automationRes.GetRawResponse(), data)
 			return
 		}
 		if automationRes == nil {
@@ -426,10 +459,7 @@ func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest
 			return
 		}
 		if automationRes.StatusCode != 202 && automationRes.StatusCode != 200 {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from automation API. Got an unexpected response code %v", automationRes.StatusCode),
-				debugResponse(automationRes.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from automation API. Got an unexpected response code %v", automationRes.StatusCode), nil, automationRes.RawResponse, data)
 			return
 		}
 
@@ -439,10 +469,7 @@ func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest
 		}
 		readRes, err := r.client.Tagbasedpolicies.GetTagBasedPolicy(ctx, readRequest)
 		if err != nil {
-			resp.Diagnostics.AddError("failure to read updated segment", err.Error())
-			if readRes != nil && readRes.RawResponse != nil {
-				resp.Diagnostics.AddError("unexpected http request/response", debugResponse(readRes.RawResponse))
-			}
+			addAPIError(ctx, &resp.Diagnostics, "failure to read updated segment", err, readRes.GetRawResponse(), data)
 			return
 		}
 		if readRes == nil {
@@ -450,14 +477,11 @@ func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest
 			return
 		}
 		if readRes.StatusCode != 200 {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", readRes.StatusCode),
-				debugResponse(readRes.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", readRes.StatusCode), nil, readRes.RawResponse, data)
 			return
 		}
 		if !(readRes.TagBasedPolicyResponse != nil) {
-			resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(readRes.RawResponse))
+			addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, readRes.RawResponse, data)
 			return
 		}
 
@@ -473,6 +497,9 @@ func (r *SegmentResource) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (r *SegmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.State, timeoutRead, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *SegmentResourceModel
 	var item types.Object
 
@@ -510,27 +537,25 @@ func (r *SegmentResource) Read(ctx context.Context, req resource.ReadRequest, re
 		TagbasedpolicyID: tagbasedpolicyID,
 	}
 	res, err := r.client.Tagbasedpolicies.GetTagBasedPolicy(ctx, request)
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
 		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
 		return
 	}
-	if res.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.TagBasedPolicyResponse != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedTagBasedPolicyResponse(res.TagBasedPolicyResponse)
@@ -598,6 +623,8 @@ func (r *SegmentResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.Plan, timeoutUpdate, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
 
 	var planData *SegmentResourceModel
 	var stateData *SegmentResourceModel
@@ -780,10 +807,7 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 		res, err := r.client.Tagbasedpolicies.UpdateTagBasedPolicyMetadata(ctx, request)
 
 		if err != nil {
-			resp.Diagnostics.AddError("failure to update metadata", err.Error())
-			if res != nil && res.RawResponse != nil {
-				resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-			}
+			addAPIError(ctx, &resp.Diagnostics, "failure to update metadata", err, res.GetRawResponse(), data)
 			return
 		}
 
@@ -794,7 +818,7 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 
 		// The expected response codes are 204 (No Content), 200 (OK), or 202 (Accepted)
 		if res.StatusCode != 204 && res.StatusCode != 200 && res.StatusCode != 202 {
-			resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 			return
 		}
 
@@ -828,19 +852,9 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 
 		// Handle errors
 		if err != nil {
-			// Check if the error is due to a 202 status code, which is actually a success
-			if res != nil && res.StatusCode == 202 {
-				tflog.Info(ctx, fmt.Sprintf("Received 202 status code for template operation, which is a success despite the error: %s", err.Error()))
-				// This is actually a success, so we'll continue
-			} else {
-				tflog.Error(ctx, fmt.Sprintf("Error from TagBasedPolicyBulkTemplateUnApply: %s", err.Error()))
-				resp.Diagnostics.AddError("failure to invoke API", err.Error())
-				if res != nil && res.RawResponse != nil {
-					tflog.Error(ctx, fmt.Sprintf("Response details: %s", debugResponse(res.RawResponse)))
-					resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-				}
-				return
-			}
+			tflog.Error(ctx, fmt.Sprintf("Error from TagBasedPolicyBulkTemplateUnApply: %s", err.Error()))
+			addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
+			return
 		}
 		if res == nil {
 			tflog.Error(ctx, "Received nil response from TagBasedPolicyBulkTemplateUnApply")
@@ -849,10 +863,7 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 		if res.StatusCode != 202 && res.StatusCode != 204 && res.StatusCode != 200 {
 			tflog.Error(ctx, fmt.Sprintf("Unexpected status code: %d", res.StatusCode))
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode),
-				debugResponse(res.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 			return
 		}
 
@@ -886,19 +897,9 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 
 		// Handle errors
 		if err != nil {
-			// Check if the error is due to a 202 status code, which is actually a success
-			if res != nil && res.StatusCode == 202 {
-				tflog.Info(ctx, fmt.Sprintf("Received 202 status code for template operation, which is a success despite the error: %s", err.Error()))
-				// This is actually a success, so we'll continue
-			} else {
-				tflog.Error(ctx, fmt.Sprintf("Error from TagBasedPolicyBulkTemplateApply: %s", err.Error()))
-				resp.Diagnostics.AddError("failure to invoke API", err.Error())
-				if res != nil && res.RawResponse != nil {
-					tflog.Error(ctx, fmt.Sprintf("Response details: %s", debugResponse(res.RawResponse)))
-					resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-				}
-				return
-			}
+			tflog.Error(ctx, fmt.Sprintf("Error from TagBasedPolicyBulkTemplateApply: %s", err.Error()))
+			addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
+			return
 		}
 		if res == nil {
 			tflog.Error(ctx, "Received nil response from TagBasedPolicyBulkTemplateApply")
@@ -907,10 +908,7 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 		if res.StatusCode != 202 && res.StatusCode != 204 && res.StatusCode != 200 {
 			tflog.Error(ctx, fmt.Sprintf("Unexpected status code: %d", res.StatusCode))
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode),
-				debugResponse(res.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 			return
 		}
 
@@ -944,29 +942,16 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 
 		// Handle errors - match the pattern in template_resource.go
 		if err != nil {
-			// Check if the error is due to a 202 status code, which is actually a success
-			if res != nil && res.StatusCode == 202 {
-				tflog.Info(ctx, fmt.Sprintf("Received 202 status code for namednetwork removal, which is a success despite the error: %s", err.Error()))
-				// This is actually a success, so we'll continue
-			} else {
-				tflog.Error(ctx, fmt.Sprintf("Error from TagBasedPolicyBulkNamedNetworkUnApply: %s", err.Error()))
-				resp.Diagnostics.AddError("Failed to remove named networks from segment", err.Error())
-				if res != nil && res.RawResponse != nil {
-					tflog.Error(ctx, fmt.Sprintf("Response details: %s", debugResponse(res.RawResponse)))
-					resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-				}
-				return
-			}
+			tflog.Error(ctx, fmt.Sprintf("Error from TagBasedPolicyBulkNamedNetworkUnApply: %s", err.Error()))
+			addAPIError(ctx, &resp.Diagnostics, "Failed to remove named networks from segment", err, res.GetRawResponse(), data)
+			return
 		}
 
 		// Check for non-success status code (202 Accepted is the expected success code for this API)
 		// We also accept 200 OK and 204 No Content as success codes
 		if res != nil && res.StatusCode != 202 && res.StatusCode != 204 && res.StatusCode != 200 {
 			tflog.Error(ctx, fmt.Sprintf("Unexpected status code from TagBasedPolicyBulkNamedNetworkUnApply: %d", res.StatusCode))
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode),
-				debugResponse(res.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 			return
 		}
 
@@ -1005,28 +990,15 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 
 		// Handle errors - match the pattern in template_resource.go
 		if err != nil {
-			// Check if the error is due to a 202 status code, which is actually a success
-			if res != nil && res.StatusCode == 202 {
-				tflog.Info(ctx, fmt.Sprintf("Received 202 status code for namednetwork apply, which is a success despite the error: %s", err.Error()))
-				// This is actually a success, so we'll continue
-			} else {
-				tflog.Error(ctx, fmt.Sprintf("Error from TagBasedPolicyBulkNamedNetworkApply: %s", err.Error()))
-				resp.Diagnostics.AddError("Failed to add named networks to segment", err.Error())
-				if res != nil && res.RawResponse != nil {
-					tflog.Error(ctx, fmt.Sprintf("Response details: %s", debugResponse(res.RawResponse)))
-					resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-				}
-				return
-			}
+			tflog.Error(ctx, fmt.Sprintf("Error from TagBasedPolicyBulkNamedNetworkApply: %s", err.Error()))
+			addAPIError(ctx, &resp.Diagnostics, "Failed to add named networks to segment", err, res.GetRawResponse(), data)
+			return
 		}
 		// Check for non-success status code (202 Accepted is the expected success code for this API)
 		// We also accept 200 OK and 204 No Content as success codes
 		if res != nil && res.StatusCode != 202 && res.StatusCode != 200 && res.StatusCode != 204 {
 			tflog.Error(ctx, fmt.Sprintf("Unexpected status code from TagBasedPolicyBulkNamedNetworkApply: %d", res.StatusCode))
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode),
-				debugResponse(res.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 			return
 		}
 
@@ -1111,10 +1083,7 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 		// Call the automation configuration API
 		automationRes, err := r.client.Tagbasedpolicies.AutomationConfiguration(ctx, automationRequest)
 		if err != nil {
-			resp.Diagnostics.AddError("failure to invoke automation API", err.Error())
-			if automationRes != nil && automationRes.RawResponse != nil {
-				resp.Diagnostics.AddError("unexpected http request/response", debugResponse(automationRes.RawResponse))
-			}
+			addAPIError(ctx, &resp.Diagnostics, "failure to invoke automation API", err, automationRes.GetRawResponse(), data)
 			return
 		}
 		if automationRes == nil {
@@ -1122,10 +1091,7 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 			return
 		}
 		if automationRes.StatusCode != 202 && automationRes.StatusCode != 200 {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from automation API. Got an unexpected response code %v", automationRes.StatusCode),
-				debugResponse(automationRes.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from automation API. Got an unexpected response code %v", automationRes.StatusCode), nil, automationRes.RawResponse, data)
 			return
 		}
 	}
@@ -1147,19 +1113,13 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 			return r.client.Tagbasedpolicies.GetTagBasedPolicy(ctx, readRequest)
 		}()
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to read updated segment", err.Error())
-			if readRes != nil && readRes.RawResponse != nil {
-				resp.Diagnostics.AddError("unexpected http request/response", debugResponse(readRes.RawResponse))
-			}
+			addAPIError(ctx, &resp.Diagnostics, "Failed to read updated segment", err, readRes.GetRawResponse(), data)
 			return
 		}
 
 		// Check for non-success status code
 		if readRes != nil && readRes.StatusCode != 200 {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", readRes.StatusCode),
-				debugResponse(readRes.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", readRes.StatusCode), nil, readRes.RawResponse, data)
 			return
 		}
 
@@ -1223,6 +1183,9 @@ func (r *SegmentResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.State, timeoutDelete, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *SegmentResourceModel
 	var item types.Object
 
@@ -1263,10 +1226,7 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 			"error":      err.Error(),
 			"step":       "pre_delete_get",
 		})
-		resp.Diagnostics.AddError("failure to get segment before deletion", err.Error())
-		if getRes != nil && getRes.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(getRes.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to get segment before deletion", err, getRes.GetRawResponse(), data)
 		return
 	}
 
@@ -1337,53 +1297,19 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 		// Handle errors
 		if err != nil {
-			// Check if the error is due to a 202 status code, which is actually a success
-			if nnRes != nil && nnRes.StatusCode == 202 {
-				tflog.Info(ctx, "Named network detachment succeeded with 202 status", map[string]interface{}{
-					"segment_id":    tagbasedpolicyID,
-					"status_code":   202,
-					"error_message": err.Error(),
-					"step":          "named_network_detach_response",
-				})
-				// This is actually a success, so we'll continue
-			} else {
-				var statusCode int
-				if nnRes != nil {
-					statusCode = nnRes.StatusCode
-				} else {
-					statusCode = -1
-				}
-				tflog.Error(ctx, "Failed to detach named networks", map[string]interface{}{
-					"segment_id":  tagbasedpolicyID,
-					"error":       err.Error(),
-					"status_code": statusCode,
-					"step":        "named_network_detach_error",
-				})
-				resp.Diagnostics.AddError("failure to detach named networks", err.Error())
-				if nnRes != nil && nnRes.RawResponse != nil {
-					responseDetails := debugResponse(nnRes.RawResponse)
-					tflog.Error(ctx, "Response details from failed named network detachment", map[string]interface{}{
-						"segment_id":       tagbasedpolicyID,
-						"response_details": responseDetails,
-						"step":             "named_network_detach_error_details",
-					})
-					resp.Diagnostics.AddError("unexpected http request/response", responseDetails)
-				}
-				return
-			}
-		} else {
-			var statusCode int
-			if nnRes != nil {
-				statusCode = nnRes.StatusCode
-			} else {
-				statusCode = -1
-			}
-			tflog.Info(ctx, "Successfully detached named networks", map[string]interface{}{
-				"segment_id":  tagbasedpolicyID,
-				"status_code": statusCode,
-				"step":        "named_network_detach_success",
+			tflog.Error(ctx, "Failed to detach named networks", map[string]interface{}{
+				"segment_id": tagbasedpolicyID,
+				"error":      err.Error(),
+				"step":       "named_network_detach_error",
 			})
+			addAPIError(ctx, &resp.Diagnostics, "failure to detach named networks", err, nnRes.GetRawResponse(), data)
+			return
 		}
+		tflog.Info(ctx, "Successfully detached named networks", map[string]interface{}{
+			"segment_id":  tagbasedpolicyID,
+			"status_code": nnRes.StatusCode,
+			"step":        "named_network_detach_success",
+		})
 	}
 
 	// If the segment has templates, detach them
@@ -1438,53 +1364,19 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 		// Handle errors
 		if err != nil {
-			// Check if the error is due to a 202 status code, which is actually a success
-			if templateRes != nil && templateRes.StatusCode == 202 {
-				tflog.Info(ctx, "Template detachment succeeded with 202 status", map[string]interface{}{
-					"segment_id":    tagbasedpolicyID,
-					"status_code":   202,
-					"error_message": err.Error(),
-					"step":          "template_detach_response",
-				})
-				// This is actually a success, so we'll continue
-			} else {
-				var statusCode int
-				if templateRes != nil {
-					statusCode = templateRes.StatusCode
-				} else {
-					statusCode = -1
-				}
-				tflog.Error(ctx, "Failed to detach templates", map[string]interface{}{
-					"segment_id":  tagbasedpolicyID,
-					"error":       err.Error(),
-					"status_code": statusCode,
-					"step":        "template_detach_error",
-				})
-				resp.Diagnostics.AddError("failure to detach templates", err.Error())
-				if templateRes != nil && templateRes.RawResponse != nil {
-					responseDetails := debugResponse(templateRes.RawResponse)
-					tflog.Error(ctx, "Response details from failed template detachment", map[string]interface{}{
-						"segment_id":       tagbasedpolicyID,
-						"response_details": responseDetails,
-						"step":             "template_detach_error_details",
-					})
-					resp.Diagnostics.AddError("unexpected http request/response", responseDetails)
-				}
-				return
-			}
-		} else {
-			var statusCode int
-			if templateRes != nil {
-				statusCode = templateRes.StatusCode
-			} else {
-				statusCode = -1
-			}
-			tflog.Info(ctx, "Successfully detached templates", map[string]interface{}{
-				"segment_id":  tagbasedpolicyID,
-				"status_code": statusCode,
-				"step":        "template_detach_success",
+			tflog.Error(ctx, "Failed to detach templates", map[string]interface{}{
+				"segment_id": tagbasedpolicyID,
+				"error":      err.Error(),
+				"step":       "template_detach_error",
 			})
+			addAPIError(ctx, &resp.Diagnostics, "failure to detach templates", err, templateRes.GetRawResponse(), data)
+			return
 		}
+		tflog.Info(ctx, "Successfully detached templates", map[string]interface{}{
+			"segment_id":  tagbasedpolicyID,
+			"status_code": templateRes.StatusCode,
+			"step":        "template_detach_success",
+		})
 	}
 
 	// Proceed with deletion
@@ -1506,8 +1398,8 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 			"step":       "segment_delete_error",
 		})
 
-		// Check for 404 errors in different ways
-		if res != nil && res.StatusCode == 404 {
+		var notFound *sdkerrors.NotFoundError
+		if errors.As(err, &notFound) {
 			tflog.Info(ctx, "Segment already deleted (404 status code)", map[string]interface{}{
 				"segment_id": tagbasedpolicyID,
 				"step":       "segment_delete_404",
@@ -1515,18 +1407,8 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 			return
 		}
 
-		// Also check for 404 in the error message
-		if strings.Contains(err.Error(), "Status 404") {
-			tflog.Info(ctx, "Segment already deleted (404 in error message)", map[string]interface{}{
-				"segment_id": tagbasedpolicyID,
-				"error":      err.Error(),
-				"step":       "segment_delete_404_message",
-			})
-			return
-		}
-
-		// Check for 409 conflict in the error message
-		if strings.Contains(err.Error(), "Status 409") {
+		var conflict *sdkerrors.ConflictError
+		if errors.As(err, &conflict) {
 			tflog.Info(ctx, "Segment deletion returned 409 conflict - background process still running, will retry with backoff", map[string]interface{}{
 				"segment_id": tagbasedpolicyID,
 				"error":      err.Error(),
@@ -1548,7 +1430,13 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 					"delay_sec":  currentDelay,
 					"step":       "segment_delete_backoff",
 				})
-				time.Sleep(time.Duration(currentDelay) * time.Second)
+				setOperationStep(ctx, "waiting to retry the deletion after a 409 conflict")
+				select {
+				case <-ctx.Done():
+					resp.Diagnostics.AddError("failure to delete segment", fmt.Sprintf("Stopped waiting to retry the deletion: %s", ctx.Err()))
+					return
+				case <-time.After(time.Duration(currentDelay) * time.Second):
+				}
 
 				// Try deletion
 				tflog.Info(ctx, "Retrying segment deletion", map[string]interface{}{
@@ -1560,7 +1448,7 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 				retryRes, retryErr := r.client.Tagbasedpolicies.DeleteTagBasedPolicy(ctx, request)
 
 				// Success
-				if retryErr == nil && (retryRes.StatusCode == 200 || retryRes.StatusCode == 202 || retryRes.StatusCode == 404) {
+				if retryErr == nil && (retryRes.StatusCode == 200 || retryRes.StatusCode == 202) {
 					tflog.Info(ctx, "Segment deletion successful on retry", map[string]interface{}{
 						"segment_id":  tagbasedpolicyID,
 						"status_code": retryRes.StatusCode,
@@ -1571,7 +1459,7 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 				}
 
 				// Handle 404 in error
-				if retryErr != nil && strings.Contains(retryErr.Error(), "Status 404") {
+				if errors.As(retryErr, &notFound) {
 					tflog.Info(ctx, "Segment already deleted on retry", map[string]interface{}{
 						"segment_id": tagbasedpolicyID,
 						"attempt":    attempt,
@@ -1581,7 +1469,7 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 				}
 
 				// If still 409 and this is the last attempt, treat as success
-				if retryErr != nil && strings.Contains(retryErr.Error(), "Status 409") && attempt == maxRetries {
+				if errors.As(retryErr, &conflict) && attempt == maxRetries {
 					tflog.Info(ctx, "Segment deletion still getting 409 after max retries - treating as success", map[string]interface{}{
 						"segment_id": tagbasedpolicyID,
 						"attempt":    attempt,
@@ -1612,17 +1500,7 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 		}
 
 		// If we get here, it's a real error
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			responseDetails := debugResponse(res.RawResponse)
-			tflog.Error(ctx, "Response details from failed segment deletion", map[string]interface{}{
-				"segment_id":       tagbasedpolicyID,
-				"response_details": responseDetails,
-				"status_code":      res.StatusCode,
-				"step":             "segment_delete_error_details",
-			})
-			resp.Diagnostics.AddError("unexpected http request/response", responseDetails)
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 
@@ -1635,13 +1513,13 @@ func (r *SegmentResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	// Verify we have a success status code (200, 202, or 404)
-	if res.StatusCode != 200 && res.StatusCode != 202 && res.StatusCode != 404 {
+	// Verify we have a success status code (200 or 202)
+	if res.StatusCode != 200 && res.StatusCode != 202 {
 		tflog.Error(ctx, "Unexpected status code from segment deletion", map[string]interface{}{
 			"segment_id":  tagbasedpolicyID,
 			"status_code": res.StatusCode,
 		})
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 
@@ -1662,7 +1540,7 @@ func (r *SegmentResource) ImportState(ctx context.Context, req resource.ImportSt
 
 	// If not a UUID, assume it's a name and look up the segment
 	// Create a search criteria that filters by the segment name
-	searchCriteria := fmt.Sprintf("tagBasedPolicyName = '%s'", req.ID)
+	searchCriteria := criteria.Eq(criteria.Field("tagBasedPolicyName"), req.ID).String()
 	listReq := operations.ListTagBasedPoliciesRequest{
 		SearchInput: shared.SearchInput{
 			Criteria: searchCriteria,
@@ -1675,9 +1553,10 @@ func (r *SegmentResource) ImportState(ctx context.Context, req resource.ImportSt
 		"search_criteria": listReq.SearchInput.Criteria,
 	})
 
-	// Try to get the segments
-	policies, err := r.client.Tagbasedpolicies.ListTagBasedPolicies(ctx, listReq)
-
+	// Search every page of the segments
+	policy, found, err := r.client.Tagbasedpolicies.ListTagBasedPoliciesPager(listReq, sdk.DefaultPageSize).Find(ctx, func(policy shared.TagBasedPolicySummary) bool {
+		return policy.TagBasedPolicyName != nil && *policy.TagBasedPolicyName == req.ID && policy.TagBasedPolicyID != nil
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving segments",
@@ -1686,27 +1565,13 @@ func (r *SegmentResource) ImportState(ctx context.Context, req resource.ImportSt
 		return
 	}
 
-	// Process the JSON response
-	if policies.TagBasedPolicies != nil && len(policies.TagBasedPolicies.Items) > 0 {
-		// Find the segment with the matching name
-		var foundID string
-		for _, policy := range policies.TagBasedPolicies.Items {
-			if policy.TagBasedPolicyName != nil && *policy.TagBasedPolicyName == req.ID {
-				if policy.TagBasedPolicyID != nil {
-					foundID = *policy.TagBasedPolicyID
-					break
-				}
-			}
-		}
-
-		if foundID != "" {
-			tflog.Info(ctx, "Found segment", map[string]interface{}{
-				"id":   foundID,
-				"name": req.ID,
-			})
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), foundID)...)
-			return
-		}
+	if found {
+		tflog.Info(ctx, "Found segment", map[string]interface{}{
+			"id":   *policy.TagBasedPolicyID,
+			"name": req.ID,
+		})
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), *policy.TagBasedPolicyID)...)
+		return
 	}
 
 	resp.Diagnostics.AddError(
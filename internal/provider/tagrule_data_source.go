@@ -4,9 +4,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -132,56 +135,37 @@ func (r *TagRuleDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	} else if !data.RuleName.IsNull() && data.RuleName.ValueString() != "" {
 		// If we have a name, use ListTagRules with a search criteria
 		ruleName := data.RuleName.ValueString()
-		searchCriteria := fmt.Sprintf("ruleName = '%s'", ruleName)
+		searchCriteria := criteria.Eq(criteria.Field("ruleName"), ruleName).String()
 		listReq := operations.ListTagRulesRequest{
 			SearchInput: shared.SearchInput{
 				Criteria: searchCriteria,
 			},
 		}
 
-		// Call the API
-		rules, err := r.client.Tagrules.ListTagRules(ctx, listReq)
-		if err != nil {
+		// Search every page of the results for the name
+		rule, found, listErr := r.client.Tagrules.ListTagRulesPager(listReq, sdk.DefaultPageSize).Find(ctx, func(rule shared.TagRule) bool {
+			return rule.RuleName != nil && *rule.RuleName == ruleName && rule.ID != nil
+		})
+		if listErr != nil {
 			resp.Diagnostics.AddError(
 				"Error retrieving tag rules",
-				fmt.Sprintf("Could not list tag rules to find by name: %s", err),
+				fmt.Sprintf("Could not list tag rules to find by name: %s", listErr),
 			)
 			return
 		}
-
-		// Process the response
-		if rules.TagRules != nil && len(rules.TagRules.Items) > 0 {
-			// Find the tag rule with the matching name
-			var foundID string
-			for _, rule := range rules.TagRules.Items {
-				if rule.RuleName != nil && *rule.RuleName == ruleName {
-					if rule.ID != nil {
-						foundID = *rule.ID
-						break
-					}
-				}
-			}
-
-			if foundID != "" {
-				// Now use GetTagRule to get the full details
-				request := operations.GetTagRuleRequest{
-					RuleID: foundID,
-				}
-				res, err = r.client.Tagrules.GetTagRule(ctx, request)
-			} else {
-				resp.Diagnostics.AddError(
-					"Tag rule not found",
-					fmt.Sprintf("No tag rule found with name: %s", ruleName),
-				)
-				return
-			}
-		} else {
+		if !found {
 			resp.Diagnostics.AddError(
 				"Tag rule not found",
-				fmt.Sprintf("No tag rules found matching the criteria: %s", searchCriteria),
+				fmt.Sprintf("No tag rule found with name: %s", ruleName),
 			)
 			return
 		}
+
+		// Now use GetTagRule to get the full details
+		request := operations.GetTagRuleRequest{
+			RuleID: *rule.ID,
+		}
+		res, err = r.client.Tagrules.GetTagRule(ctx, request)
 	} else {
 		// If we don't have either ID or name, return an error
 		resp.Diagnostics.AddError(
@@ -190,27 +174,25 @@ func (r *TagRuleDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		)
 		return
 	}
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
 		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
 		return
 	}
-	if res.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.TagRule != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedTagRule(res.TagRule)
@@ -4,13 +4,17 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
@@ -18,6 +22,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TagRuleResource{}
 var _ resource.ResourceWithImportState = &TagRuleResource{}
+var _ resource.ResourceWithModifyPlan = &TagRuleResource{}
 
 func NewTagRuleResource() resource.Resource {
 	return &TagRuleResource{}
@@ -26,6 +31,8 @@ func NewTagRuleResource() resource.Resource {
 // TagRuleResource defines the resource implementation.
 type TagRuleResource struct {
 	client *sdk.Xshield
+	fields *tenantFields
+	assets *assetSnapshot
 }
 
 // TagRuleResourceModel describes the resource data model.
@@ -37,6 +44,7 @@ type TagRuleResourceModel struct {
 	RuleDescription types.String            `tfsdk:"rule_description"`
 	RuleEnabled     types.Bool              `tfsdk:"rule_enabled"`
 	RuleName        types.String            `tfsdk:"rule_name"`
+	Timeouts        timeouts.Value          `tfsdk:"timeouts"`
 }
 
 func (r *TagRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,6 +68,9 @@ func (r *TagRuleResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 			"rule_criteria": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					criteriaSyntax(),
+				},
 			},
 			"rule_description": schema.StringAttribute{
 				Computed: true,
@@ -74,6 +85,9 @@ func (r *TagRuleResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -83,21 +97,56 @@ func (r *TagRuleResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.Xshield)
+	data, ok := req.ProviderData.(*resourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.fields = data.fields
+	r.assets = data.assets
+}
+
+// ModifyPlan warns about criteria and on_match keys and values that are not known to the
+// tenant, and previews the assets changed criteria will match
+func (r *TagRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the tag rule is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// The attributes are read one by one, as on_match elements can be unknown until apply
+	var configCriteria, stateCriteria types.String
+	var configOnMatch, stateOnMatch types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rule_criteria"), &configCriteria)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("on_match"), &configOnMatch)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("rule_criteria"), &stateCriteria)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("on_match"), &stateOnMatch)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !configCriteria.Equal(stateCriteria) {
+		validateCriteriaFields(ctx, r.fields, path.Root("rule_criteria"), configCriteria, &resp.Diagnostics)
+		previewCriteriaMatches(ctx, r.assets, path.Root("rule_criteria"), stateCriteria, configCriteria, &resp.Diagnostics)
+	}
+	if !configOnMatch.Equal(stateOnMatch) {
+		validateOnMatchFields(ctx, r.fields, path.Root("on_match"), configOnMatch, &resp.Diagnostics)
+	}
 }
 
 func (r *TagRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.Plan, timeoutCreate, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *TagRuleResourceModel
 	var plan types.Object
 
@@ -118,10 +167,7 @@ func (r *TagRuleResource) Create(ctx context.Context, req resource.CreateRequest
 	request := *data.ToSharedTagRuleInput()
 	res, err := r.client.Tagrules.CreateTagRule(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
@@ -129,11 +175,11 @@ func (r *TagRuleResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 	if res.StatusCode != 202 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.TagRule != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedTagRule(res.TagRule)
@@ -144,6 +190,9 @@ func (r *TagRuleResource) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (r *TagRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.State, timeoutRead, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *TagRuleResourceModel
 	var item types.Object
 
@@ -167,27 +216,25 @@ func (r *TagRuleResource) Read(ctx context.Context, req resource.ReadRequest, re
 		RuleID: ruleID,
 	}
 	res, err := r.client.Tagrules.GetTagRule(ctx, request)
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
 		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
 		return
 	}
-	if res.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.TagRule != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedTagRule(res.TagRule)
@@ -197,6 +244,9 @@ func (r *TagRuleResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *TagRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.Plan, timeoutUpdate, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *TagRuleResourceModel
 	var plan types.Object
 
@@ -219,10 +269,7 @@ func (r *TagRuleResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 	res, err := r.client.Tagrules.UpdateTagRule(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
@@ -230,11 +277,11 @@ func (r *TagRuleResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 	if res.StatusCode != 200 && res.StatusCode != 202 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.TagRule != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedTagRule(res.TagRule)
@@ -245,6 +292,9 @@ func (r *TagRuleResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *TagRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.State, timeoutDelete, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *TagRuleResourceModel
 	var item types.Object
 
@@ -269,10 +319,7 @@ func (r *TagRuleResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 	res, err := r.client.Tagrules.DeleteTagRule(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
@@ -280,7 +327,7 @@ func (r *TagRuleResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TagsDataSource{}
+var _ datasource.DataSourceWithConfigure = &TagsDataSource{}
+
+func NewTagsDataSource() datasource.DataSource {
+	return &TagsDataSource{}
+}
+
+// TagsDataSource is the data source implementation.
+type TagsDataSource struct {
+	client *sdk.Xshield
+}
+
+// TagsDataSourceModel describes the data model.
+type TagsDataSourceModel struct {
+	Names       []types.String            `tfsdk:"names"`
+	TagID       types.String              `tfsdk:"tag_id"`
+	Tags        []tfTypes.Tag             `tfsdk:"tags"`
+	Values      []types.String            `tfsdk:"values"`
+	ValuesByKey map[string][]types.String `tfsdk:"values_by_key"`
+}
+
+// Metadata returns the data source type name.
+func (r *TagsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tags"
+}
+
+// Schema defines the schema for the data source.
+func (r *TagsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := tagListSchemaAttributes()
+	attributes["tag_id"] = schema.StringAttribute{
+		Optional:    true,
+		Description: `Only return the tag with this ID. Conflicts with names and values.`,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tags DataSource",
+
+		Attributes: attributes,
+	}
+}
+
+func (r *TagsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.Xshield)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *TagsDataSourceModel
+	var item types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &item)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(item.As(ctx, &data, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags []shared.Tag
+	if data.TagID.ValueString() != "" {
+		res, err := r.client.Tags.GetTag(ctx, operations.GetTagRequest{TagID: data.TagID.ValueString()})
+		if err != nil {
+			addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
+			return
+		}
+		if res == nil {
+			resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
+			return
+		}
+		if res.StatusCode != 200 {
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
+			return
+		}
+		if res.Tag != nil {
+			tags = append(tags, *res.Tag)
+		}
+	} else {
+		names := stringValues(data.Names)
+		values := stringValues(data.Values)
+		request := operations.ListTagsRequest{
+			Name:  names,
+			Value: values,
+		}
+		var err error
+		tags, err = r.client.Tags.ListTagsPager(request, assetListPageSize).Collect(ctx)
+		if err != nil {
+			addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, nil, data)
+			return
+		}
+	}
+
+	data.Tags, data.ValuesByKey = refreshTagsFromShared(tags)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// tagListSchemaAttributes returns the filters and results shared by the tag list data sources
+func tagListSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"names": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.ConflictsWith(path.MatchRoot("tag_id")),
+			},
+			Description: `Only return tags with one of these keys`,
+		},
+		"tags": schema.ListNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"is_cloud_tag": schema.BoolAttribute{
+						Computed:    true,
+						Description: `Set to true if tag is mirror of cloud provider resource tag (AWS / AZURE / GCP / OCI)`,
+					},
+					"key": schema.StringAttribute{
+						Computed:    true,
+						Description: `Tag Name, human readable name for this tag e.g Environment`,
+					},
+					"value": schema.StringAttribute{
+						Computed:    true,
+						Description: `Tag Value, human readable value for this tag e.g Development`,
+					},
+				},
+			},
+		},
+		"values": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.ConflictsWith(path.MatchRoot("tag_id")),
+			},
+			Description: `Only return tags with one of these values`,
+		},
+		"values_by_key": schema.MapAttribute{
+			Computed:    true,
+			ElementType: types.ListType{ElemType: types.StringType},
+			Description: `Distinct values of the returned tags, sorted, by tag key`,
+		},
+	}
+}
+
+// stringValues converts Terraform string values to a slice of their non empty values
+func stringValues(values []types.String) []string {
+	var out []string
+	for _, value := range values {
+		if value.ValueString() != "" {
+			out = append(out, value.ValueString())
+		}
+	}
+	return out
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// refreshTagsFromShared converts tags to their Terraform representation and groups their
+// distinct values by key
+func refreshTagsFromShared(tags []shared.Tag) ([]tfTypes.Tag, map[string][]types.String) {
+	values := map[string]map[string]bool{}
+
+	result := []tfTypes.Tag{}
+	for _, tagsItem := range tags {
+		var tags1 tfTypes.Tag
+		tags1.ID = types.StringPointerValue(tagsItem.ID)
+		tags1.IsCloudTag = types.BoolPointerValue(tagsItem.IsCloudTag)
+		tags1.Key = types.StringValue(tagsItem.Key)
+		tags1.Value = types.StringValue(tagsItem.Value)
+		result = append(result, tags1)
+
+		if values[tagsItem.Key] == nil {
+			values[tagsItem.Key] = map[string]bool{}
+		}
+		values[tagsItem.Key][tagsItem.Value] = true
+	}
+
+	valuesByKey := make(map[string][]types.String, len(values))
+	for key, set := range values {
+		valuesByKey[key] = sortedStringValues(set)
+	}
+
+	return result, valuesByKey
+}
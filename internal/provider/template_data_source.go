@@ -4,10 +4,13 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
 	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -270,56 +273,37 @@ func (r *TemplateDataSource) Read(ctx context.Context, req datasource.ReadReques
 	} else if !data.TemplateName.IsNull() && data.TemplateName.ValueString() != "" {
 		// If we have a name, use ListTemplates with a search criteria
 		templateName := data.TemplateName.ValueString()
-		searchCriteria := fmt.Sprintf("templateName = '%s'", templateName)
+		searchCriteria := criteria.Eq(criteria.Field("templateName"), templateName).String()
 		listReq := operations.ListTemplatesRequest{
 			SearchInput: shared.SearchInput{
 				Criteria: searchCriteria,
 			},
 		}
 
-		// Call the API
-		templates, err := r.client.Templates.ListTemplates(ctx, listReq)
-		if err != nil {
+		// Search every page of the results for the name
+		template, found, listErr := r.client.Templates.ListTemplatesPager(listReq, sdk.DefaultPageSize).Find(ctx, func(template shared.TemplateSummary) bool {
+			return template.TemplateName != nil && *template.TemplateName == templateName && template.TemplateID != nil
+		})
+		if listErr != nil {
 			resp.Diagnostics.AddError(
 				"Error retrieving templates",
-				fmt.Sprintf("Could not list templates to find by name: %s", err),
+				fmt.Sprintf("Could not list templates to find by name: %s", listErr),
 			)
 			return
 		}
-
-		// Process the response
-		if templates.Templates != nil && len(templates.Templates.Items) > 0 {
-			// Find the template with the matching name
-			var foundID string
-			for _, template := range templates.Templates.Items {
-				if template.TemplateName != nil && *template.TemplateName == templateName {
-					if template.TemplateID != nil {
-						foundID = *template.TemplateID
-						break
-					}
-				}
-			}
-
-			if foundID != "" {
-				// Now use GetTemplate to get the full details
-				request := operations.GetTemplateRequest{
-					Templateid: foundID,
-				}
-				res, err = r.client.Templates.GetTemplate(ctx, request)
-			} else {
-				resp.Diagnostics.AddError(
-					"Template not found",
-					fmt.Sprintf("No template found with name: %s", templateName),
-				)
-				return
-			}
-		} else {
+		if !found {
 			resp.Diagnostics.AddError(
 				"Template not found",
-				fmt.Sprintf("No templates found matching the criteria: %s", searchCriteria),
+				fmt.Sprintf("No template found with name: %s", templateName),
 			)
 			return
 		}
+
+		// Now use GetTemplate to get the full details
+		request := operations.GetTemplateRequest{
+			Templateid: *template.TemplateID,
+		}
+		res, err = r.client.Templates.GetTemplate(ctx, request)
 	} else {
 		// If we don't have either ID or name, return an error
 		resp.Diagnostics.AddError(
@@ -328,27 +312,25 @@ func (r *TemplateDataSource) Read(ctx context.Context, req datasource.ReadReques
 		)
 		return
 	}
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
 		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
 		return
 	}
-	if res.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.Template != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedTemplate(res.Template)
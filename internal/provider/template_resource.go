@@ -4,6 +4,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
 	speakeasy_objectvalidators "github.com/colortokens/terraform-provider-xshield/internal/validators/objectvalidators"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -24,6 +26,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
+	"github.com/colortokens/terraform-provider-xshield/internal/criteria"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -74,6 +78,7 @@ type TemplateResourceModel struct {
 	TemplatePaths        []tfTypes.MetadataPath `tfsdk:"template_paths"`
 	TemplatePorts        []tfTypes.MetadataPort `tfsdk:"template_ports"`
 	TemplateType         types.String           `tfsdk:"template_type"`
+	Timeouts             timeouts.Value         `tfsdk:"timeouts"`
 }
 
 func (r *TemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -508,6 +513,9 @@ func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -517,21 +525,24 @@ func (r *TemplateResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.Xshield)
+	data, ok := req.ProviderData.(*resourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *TemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.Plan, timeoutCreate, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *TemplateResourceModel
 	var plan types.Object
 
@@ -559,10 +570,7 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 	request := *data.ToSharedTemplate()
 	res, err := r.client.Templates.CreateTemplate(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
@@ -570,11 +578,11 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 	if res.StatusCode != 201 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.Template != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 	data.RefreshFromSharedTemplate(res.Template)
@@ -754,6 +762,9 @@ func validateTemplatePaths(ctx context.Context, data *TemplateResourceModel, dia
 }
 
 func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.State, timeoutRead, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *TemplateResourceModel
 	var item types.Object
 
@@ -778,27 +789,25 @@ func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, r
 		Templateid: templateid,
 	}
 	res, err := r.client.Templates.GetTemplate(ctx, request)
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
 		resp.Diagnostics.AddError("unexpected response from API", fmt.Sprintf("%v", res))
 		return
 	}
-	if res.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 	if !(res.Template != nil) {
-		resp.Diagnostics.AddError("unexpected response from API. Got an unexpected response body", debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, "unexpected response from API. Got an unexpected response body", nil, res.RawResponse, data)
 		return
 	}
 
@@ -865,6 +874,8 @@ func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.Plan, timeoutUpdate, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
 
 	var data *TemplateResourceModel
 	var plan types.Object
@@ -1140,19 +1151,13 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 		// Call the SDK method to update template metadata
 		res, err := r.client.Templates.EditTemplateMetadata(ctx, metadataRequest)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to update template metadata", err.Error())
-			if res != nil && res.RawResponse != nil {
-				resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-			}
+			addAPIError(ctx, &resp.Diagnostics, "Failed to update template metadata", err, res.GetRawResponse(), data)
 			return
 		}
 
 		// Check for non-success status code
 		if res != nil && res.StatusCode != 204 {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode),
-				debugResponse(res.RawResponse),
-			)
+			addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 			return
 		}
 
@@ -1187,17 +1192,9 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 			// Call the API to delete the ports and paths
 			_, err := r.client.Templates.DeleteFromTemplate(ctx, deleteRequest)
 
-			// Handle the specific error for 202 status code
 			if err != nil {
-				// Check if the error message contains "Status 202" or "Status 204"
-				// The API can return either 202 Accepted or 204 No Content
-				if strings.Contains(err.Error(), "Status 202") || strings.Contains(err.Error(), "Status 204") {
-					// This is actually a success, so we'll continue
-				} else {
-					// For any other error, report it
-					resp.Diagnostics.AddError("Failed to delete from template", err.Error())
-					return
-				}
+				addAPIError(ctx, &resp.Diagnostics, "Failed to delete from template", err, nil, data)
+				return
 			}
 
 			// Update our data with the removed ports and paths
@@ -1213,10 +1210,7 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 
 				readRes, err := r.client.Templates.GetTemplate(ctx, readRequest)
 				if err != nil {
-					resp.Diagnostics.AddError("failure to invoke API", err.Error())
-					if readRes != nil && readRes.RawResponse != nil {
-						resp.Diagnostics.AddError("unexpected http request/response", debugResponse(readRes.RawResponse))
-					}
+					addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, readRes.GetRawResponse(), data)
 					return
 				}
 
@@ -1322,10 +1316,7 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 
 				readRes, err := r.client.Templates.GetTemplate(ctx, readRequest)
 				if err != nil {
-					resp.Diagnostics.AddError("failure to invoke API", err.Error())
-					if readRes != nil && readRes.RawResponse != nil {
-						resp.Diagnostics.AddError("unexpected http request/response", debugResponse(readRes.RawResponse))
-					}
+					addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, readRes.GetRawResponse(), data)
 					return
 				}
 
@@ -1578,25 +1569,14 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 			appendRes, err := r.client.Templates.AppendToTemplate(ctx, appendRequest)
 			tflog.Info(ctx, fmt.Sprintf("AppendToTemplate API response: %v", appendRes))
 			if err != nil {
-				// Check if the error is due to a 202 status code, which is actually a success
-				if appendRes != nil && appendRes.StatusCode == 202 {
-					// This is actually a success, so we'll continue
-				} else {
-					resp.Diagnostics.AddError("Failed to append to template", err.Error())
-					if appendRes != nil && appendRes.RawResponse != nil {
-						resp.Diagnostics.AddError("unexpected http request/response", debugResponse(appendRes.RawResponse))
-					}
-					return
-				}
+				addAPIError(ctx, &resp.Diagnostics, "Failed to append to template", err, appendRes.GetRawResponse(), data)
+				return
 			}
 
 			// Check for non-success status code (202 Accepted is the expected success code for this API)
 			// We also accept 200 OK and 204 No Content as success codes
 			if appendRes != nil && appendRes.StatusCode != 202 && appendRes.StatusCode != 200 && appendRes.StatusCode != 204 {
-				resp.Diagnostics.AddError(
-					fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", appendRes.StatusCode),
-					debugResponse(appendRes.RawResponse),
-				)
+				addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", appendRes.StatusCode), nil, appendRes.RawResponse, data)
 				return
 			}
 
@@ -1610,10 +1590,7 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 
 			readRes, err := r.client.Templates.GetTemplate(ctx, readRequest)
 			if err != nil {
-				resp.Diagnostics.AddError("failure to invoke API", err.Error())
-				if readRes != nil && readRes.RawResponse != nil {
-					resp.Diagnostics.AddError("unexpected http request/response", debugResponse(readRes.RawResponse))
-				}
+				addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, readRes.GetRawResponse(), data)
 				return
 			}
 
@@ -1882,6 +1859,9 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, timeout := withOperationTimeout(ctx, req.State, timeoutDelete, &resp.Diagnostics)
+	defer timeout.done(&resp.Diagnostics)
+
 	var data *TemplateResourceModel
 	var item types.Object
 
@@ -1907,10 +1887,7 @@ func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 	res, err := r.client.Templates.DeleteTemplate(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("failure to invoke API", err.Error())
-		if res != nil && res.RawResponse != nil {
-			resp.Diagnostics.AddError("unexpected http request/response", debugResponse(res.RawResponse))
-		}
+		addAPIError(ctx, &resp.Diagnostics, "failure to invoke API", err, res.GetRawResponse(), data)
 		return
 	}
 	if res == nil {
@@ -1918,7 +1895,7 @@ func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 	if res.StatusCode != 204 {
-		resp.Diagnostics.AddError(fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), debugResponse(res.RawResponse))
+		addAPIError(ctx, &resp.Diagnostics, fmt.Sprintf("unexpected response from API. Got an unexpected response code %v", res.StatusCode), nil, res.RawResponse, data)
 		return
 	}
 
@@ -1941,7 +1918,7 @@ func (r *TemplateResource) ImportState(ctx context.Context, req resource.ImportS
 
 	// If not a UUID, assume it's a name and look up the template
 	// Create a search criteria that filters by the template name
-	searchCriteria := fmt.Sprintf("templateName = '%s'", req.ID)
+	searchCriteria := criteria.Eq(criteria.Field("templateName"), req.ID).String()
 	listReq := operations.ListTemplatesRequest{
 		SearchInput: shared.SearchInput{
 			Criteria: searchCriteria,
@@ -1954,9 +1931,10 @@ func (r *TemplateResource) ImportState(ctx context.Context, req resource.ImportS
 		"search_criteria": listReq.SearchInput.Criteria,
 	})
 
-	// Try to get the templates
-	templates, err := r.client.Templates.ListTemplates(ctx, listReq)
-
+	// Search every page of the templates
+	template, found, err := r.client.Templates.ListTemplatesPager(listReq, sdk.DefaultPageSize).Find(ctx, func(template shared.TemplateSummary) bool {
+		return template.TemplateName != nil && *template.TemplateName == req.ID && template.TemplateID != nil
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving templates",
@@ -1965,27 +1943,13 @@ func (r *TemplateResource) ImportState(ctx context.Context, req resource.ImportS
 		return
 	}
 
-	// Process the JSON response
-	if templates.Templates != nil && len(templates.Templates.Items) > 0 {
-		// Find the template with the matching name
-		var foundID string
-		for _, template := range templates.Templates.Items {
-			if template.TemplateName != nil && *template.TemplateName == req.ID {
-				if template.TemplateID != nil {
-					foundID = *template.TemplateID
-					break
-				}
-			}
-		}
-
-		if foundID != "" {
-			tflog.Info(ctx, "Found template", map[string]interface{}{
-				"id":   foundID,
-				"name": req.ID,
-			})
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), foundID)...)
-			return
-		}
+	if found {
+		tflog.Info(ctx, "Found template", map[string]interface{}{
+			"id":   *template.TemplateID,
+			"name": req.ID,
+		})
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), *template.TemplateID)...)
+		return
 	}
 
 	resp.Diagnostics.AddError(
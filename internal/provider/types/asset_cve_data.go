@@ -0,0 +1,17 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type AssetCVEData struct {
+	AssetID       types.String  `tfsdk:"asset_id"`
+	AssetName     types.String  `tfsdk:"asset_name"`
+	CveID         types.String  `tfsdk:"cve_id"`
+	CvssScore     types.Float64 `tfsdk:"cvss_score"`
+	Description   types.String  `tfsdk:"description"`
+	ExploitURL    types.String  `tfsdk:"exploit_url"`
+	Product       types.String  `tfsdk:"product"`
+	Severity      types.Int64   `tfsdk:"severity"`
+	SeverityLevel types.String  `tfsdk:"severity_level"`
+	Vendor        types.String  `tfsdk:"vendor"`
+	Version       types.String  `tfsdk:"version"`
+}
@@ -0,0 +1,11 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type AssetIdentity struct {
+	AssetID    types.String `tfsdk:"asset_id"`
+	AssetName  types.String `tfsdk:"asset_name"`
+	Identifier types.String `tfsdk:"identifier"`
+	MatchCount types.Int64  `tfsdk:"match_count"`
+	MatchedBy  types.String `tfsdk:"matched_by"`
+}
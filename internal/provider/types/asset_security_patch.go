@@ -0,0 +1,13 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type AssetSecurityPatch struct {
+	AssetID     types.String `tfsdk:"asset_id"`
+	AssetName   types.String `tfsdk:"asset_name"`
+	NewRelease  types.String `tfsdk:"new_release"`
+	NewVersion  types.String `tfsdk:"new_version"`
+	PackageName types.String `tfsdk:"package_name"`
+	Release     types.String `tfsdk:"release"`
+	Version     types.String `tfsdk:"version"`
+}
@@ -0,0 +1,17 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type AssetVulnerablePackage struct {
+	AssetID          types.String  `tfsdk:"asset_id"`
+	AssetName        types.String  `tfsdk:"asset_name"`
+	CriticalCveCount types.Int64   `tfsdk:"critical_cve_count"`
+	CvssScore        types.Float64 `tfsdk:"cvss_score"`
+	HighCveCount     types.Int64   `tfsdk:"high_cve_count"`
+	Product          types.String  `tfsdk:"product"`
+	Severity         types.Int64   `tfsdk:"severity"`
+	TotalCveCount    types.Int64   `tfsdk:"total_cve_count"`
+	Vendor           types.String  `tfsdk:"vendor"`
+	Version          types.String  `tfsdk:"version"`
+	VulnSource       types.String  `tfsdk:"vuln_source"`
+}
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/iprange"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = IPRangeType{}
+var _ basetypes.StringValuableWithSemanticEquals = IPRangeValue{}
+
+// IPRangeType is the type of named network IP ranges, strings holding a CIDR, a single address
+// or a "first-last" range of addresses
+type IPRangeType struct {
+	basetypes.StringType
+}
+
+func (t IPRangeType) String() string {
+	return "IPRangeType"
+}
+
+func (t IPRangeType) Equal(o attr.Type) bool {
+	other, ok := o.(IPRangeType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t IPRangeType) ValueType(ctx context.Context) attr.Value {
+	return IPRangeValue{}
+}
+
+func (t IPRangeType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return IPRangeValue{StringValue: in}, nil
+}
+
+func (t IPRangeType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return IPRangeValue{StringValue: stringValue}, nil
+}
+
+// IPRangeValue is a named network IP range. Ranges covering the same addresses are
+// semantically equal however they are written, so 10.0.0.1/8 equals 10.0.0.0/8, 10.0.0.1
+// equals 10.0.0.1/32 and 10.0.0.0-10.0.0.255 equals 10.0.0.0/24.
+type IPRangeValue struct {
+	basetypes.StringValue
+}
+
+// NewIPRangeNull returns a null IP range
+func NewIPRangeNull() IPRangeValue {
+	return IPRangeValue{StringValue: basetypes.NewStringNull()}
+}
+
+// NewIPRangeValue returns a known IP range
+func NewIPRangeValue(value string) IPRangeValue {
+	return IPRangeValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewIPRangePointerValue returns a known IP range, or a null one when value is nil
+func NewIPRangePointerValue(value *string) IPRangeValue {
+	return IPRangeValue{StringValue: basetypes.NewStringPointerValue(value)}
+}
+
+func (v IPRangeValue) Type(ctx context.Context) attr.Type {
+	return IPRangeType{}
+}
+
+func (v IPRangeValue) Equal(o attr.Value) bool {
+	other, ok := o.(IPRangeValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both ranges cover the same addresses. Ranges that cannot
+// be parsed are only equal when written the same.
+func (v IPRangeValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(IPRangeValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+
+	if v.ValueString() == newValue.ValueString() {
+		return true, diags
+	}
+	prior, err := iprange.Normalize(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	updated, err := iprange.Normalize(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	return prior == updated, diags
+}
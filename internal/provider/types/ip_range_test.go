@@ -0,0 +1,45 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestIPRangeValueStringSemanticEquals(t *testing.T) {
+	tests := []struct {
+		prior, updated string
+		want           bool
+	}{
+		{prior: "10.0.0.0/24", updated: "10.0.0.0/24", want: true},
+		{prior: "10.0.0.5/24", updated: "10.0.0.0/24", want: true},
+		{prior: "10.0.0.0-10.0.0.255", updated: "10.0.0.0/24", want: true},
+		{prior: "10.0.0.1", updated: "10.0.0.1/32", want: true},
+		{prior: "2001:db8::1/64", updated: "2001:db8::/64", want: true},
+		{prior: "10.0.0.0/24", updated: "10.0.0.0/25", want: false},
+		{prior: "10.0.0.1-10.0.0.9", updated: "10.0.0.1-10.0.0.8", want: false},
+		{prior: "not a range", updated: "not a range", want: true},
+		{prior: "not a range", updated: "10.0.0.0/24", want: false},
+		{prior: "10.0.0.0/24", updated: "not a range", want: false},
+		{prior: "10.0.0.9-10.0.0.1", updated: "10.0.0.1-10.0.0.9", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.prior+" "+test.updated, func(t *testing.T) {
+			got, diags := NewIPRangeValue(test.prior).StringSemanticEquals(context.Background(), NewIPRangeValue(test.updated))
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got != test.want {
+				t.Errorf("StringSemanticEquals() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIPRangeValueStringSemanticEqualsOtherType(t *testing.T) {
+	equal, diags := NewIPRangeValue("10.0.0.0/24").StringSemanticEquals(context.Background(), basetypes.NewStringValue("10.0.0.0/24"))
+	if equal || !diags.HasError() {
+		t.Errorf("StringSemanticEquals() = %v, %v, want an error for a plain string", equal, diags)
+	}
+}
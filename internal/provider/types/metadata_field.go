@@ -0,0 +1,21 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type MetadataField struct {
+	CoreTag             types.Bool           `tfsdk:"core_tag"`
+	DataType            types.String         `tfsdk:"data_type"`
+	DisplayName         types.String         `tfsdk:"display_name"`
+	Facetable           types.Bool           `tfsdk:"facetable"`
+	ListOfValues        types.Bool           `tfsdk:"list_of_values"`
+	Multivalued         types.Bool           `tfsdk:"multivalued"`
+	Name                types.String         `tfsdk:"name"`
+	PublicName          types.String         `tfsdk:"public_name"`
+	Qualifier           types.String         `tfsdk:"qualifier"`
+	Searchable          types.Bool           `tfsdk:"searchable"`
+	Sortable            types.Bool           `tfsdk:"sortable"`
+	Unit                types.String         `tfsdk:"unit"`
+	UserDefined         types.Bool           `tfsdk:"user_defined"`
+	ValueSuggestEnabled types.Bool           `tfsdk:"value_suggest_enabled"`
+	Values              []MetadataFieldValue `tfsdk:"values"`
+}
@@ -0,0 +1,9 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type MetadataFieldValue struct {
+	Display  types.String   `tfsdk:"display"`
+	Internal types.Int64    `tfsdk:"internal"`
+	Synonyms []types.String `tfsdk:"synonyms"`
+}
@@ -0,0 +1,9 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type MetricRow struct {
+	Group      map[string]types.String  `tfsdk:"group"`
+	Keys       []types.String           `tfsdk:"keys"`
+	Statistics map[string]types.Float64 `tfsdk:"statistics"`
+}
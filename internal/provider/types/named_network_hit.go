@@ -0,0 +1,15 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type NamedNetworkHit struct {
+	AssetID               types.String `tfsdk:"asset_id"`
+	Direction             types.String `tfsdk:"direction"`
+	Domain                types.String `tfsdk:"domain"`
+	LastHitObserved       types.String `tfsdk:"last_hit_observed"`
+	NamedNetworkID        types.String `tfsdk:"named_network_id"`
+	NamedNetworkIPAddress types.String `tfsdk:"named_network_ip_address"`
+	NamedNetworkName      types.String `tfsdk:"named_network_name"`
+	Port                  types.String `tfsdk:"port"`
+	Protocol              types.String `tfsdk:"protocol"`
+}
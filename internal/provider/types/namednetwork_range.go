@@ -7,5 +7,5 @@ import "github.com/hashicorp/terraform-plugin-framework/types"
 type NamednetworkRange struct {
 	ID      types.String `tfsdk:"id"`
 	IPCount types.Int64  `tfsdk:"ip_count"`
-	IPRange types.String `tfsdk:"ip_range"`
+	IPRange IPRangeValue `tfsdk:"ip_range"`
 }
@@ -0,0 +1,11 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type SeverityCounts struct {
+	Critical types.Int64 `tfsdk:"critical"`
+	High     types.Int64 `tfsdk:"high"`
+	Medium   types.Int64 `tfsdk:"medium"`
+	Low      types.Int64 `tfsdk:"low"`
+	None     types.Int64 `tfsdk:"none"`
+}
@@ -0,0 +1,17 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type UserGroupHit struct {
+	Direction          types.String   `tfsdk:"direction"`
+	LastHitObserved    types.String   `tfsdk:"last_hit_observed"`
+	PeerAssetID        types.String   `tfsdk:"peer_asset_id"`
+	PeerDomain         types.String   `tfsdk:"peer_domain"`
+	PeerIps            []types.String `tfsdk:"peer_ips"`
+	PeerNamedNetworkID types.String   `tfsdk:"peer_named_network_id"`
+	Port               types.String   `tfsdk:"port"`
+	Protocol           types.String   `tfsdk:"protocol"`
+	UserGroupID        types.String   `tfsdk:"user_group_id"`
+	UserGroupName      types.String   `tfsdk:"user_group_name"`
+	UserName           types.String   `tfsdk:"user_name"`
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
+	sdkerrors "github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/colortokens/terraform-provider-xshield/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserGroupHitsDataSource{}
+var _ datasource.DataSourceWithConfigure = &UserGroupHitsDataSource{}
+
+func NewUserGroupHitsDataSource() datasource.DataSource {
+	return &UserGroupHitsDataSource{}
+}
+
+// UserGroupHitsDataSource is the data source implementation.
+type UserGroupHitsDataSource struct {
+	client *sdk.Xshield
+}
+
+// UserGroupHitsDataSourceModel describes the data model.
+type UserGroupHitsDataSourceModel struct {
+	Criteria        types.String           `tfsdk:"criteria"`
+	HitCount        types.Int64            `tfsdk:"hit_count"`
+	Hits            []tfTypes.UserGroupHit `tfsdk:"hits"`
+	LastHitObserved types.String           `tfsdk:"last_hit_observed"`
+	ObservedWithin  types.String           `tfsdk:"observed_within"`
+	PeerAssetIds    []types.String         `tfsdk:"peer_asset_ids"`
+	UserGroupID     types.String           `tfsdk:"user_group_id"`
+}
+
+// Metadata returns the data source type name.
+func (r *UserGroupHitsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_group_hits"
+}
+
+// Schema defines the schema for the data source.
+func (r *UserGroupHitsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "UserGroupHits DataSource",
+
+		Attributes: map[string]schema.Attribute{
+			"criteria": schema.StringAttribute{
+				Optional:    true,
+				Description: `Search criteria sent to the API to narrow down the hits`,
+			},
+			"hit_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: `Number of hits returned`,
+			},
+			"hits": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"direction": schema.StringAttribute{
+							Computed: true,
+						},
+						"last_hit_observed": schema.StringAttribute{
+							Computed: true,
+						},
+						"peer_asset_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"peer_domain": schema.StringAttribute{
+							Computed: true,
+						},
+						"peer_ips": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"peer_named_network_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"port": schema.StringAttribute{
+							Computed: true,
+						},
+						"protocol": schema.StringAttribute{
+							Computed: true,
+						},
+						"user_group_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"user_group_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"user_name": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"last_hit_observed": schema.StringAttribute{
+				Computed:    true,
+				Description: `Most recent hit over all returned hits, in RFC3339 format. Null when there are no hits.`,
+			},
+			"observed_within": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					validators.IsDuration(),
+				},
+				Description: `Only return hits observed within this duration from now, for example "720h"`,
+			},
+			"peer_asset_ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: `Distinct peer assets that exchanged traffic with the user groups`,
+			},
+			"user_group_id": schema.StringAttribute{
+				Optional:    true,
+				Description: `Only return hits of this user group`,
+			},
+		},
+	}
+}
+
+func (r *UserGroupHitsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.Xshield)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *sdk.Xshield, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *UserGroupHitsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *UserGroupHitsDataSourceModel
+	var item types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &item)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(item.As(ctx, &data, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var since time.Time
+	if !data.ObservedWithin.IsNull() && data.ObservedWithin.ValueString() != "" {
+		window, err := time.ParseDuration(data.ObservedWithin.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("observed_within"), "Invalid duration", err.Error())
+			return
+		}
+		since = time.Now().Add(-window)
+	}
+
+	hits, err := listUserGroupHits(ctx, r.client, data.Criteria.ValueString(), data.UserGroupID.ValueString())
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		// The API answers 404 when there are no hits
+		hits, err = nil, nil
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving user group hits", err.Error())
+		return
+	}
+
+	data.RefreshFromSharedUserGroupHits(hits, since)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listUserGroupHits walks every page of ListUsrGrpHits for the given criteria and, when
+// userGroupID is not empty, keeps only the hits of that user group
+func listUserGroupHits(ctx context.Context, client *sdk.Xshield, criteria string, userGroupID string) ([]shared.UserGroupHit, error) {
+	var hits []shared.UserGroupHit
+	request := shared.SearchInput{
+		Criteria: criteria,
+	}
+	for hit, err := range client.Usergroups.ListUsrGrpHitsPager(request, assetListPageSize).All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if userGroupID != "" && (hit.UserGroup == nil || hit.UserGroup.AssetID == nil || *hit.UserGroup.AssetID != userGroupID) {
+			continue
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, nil
+}
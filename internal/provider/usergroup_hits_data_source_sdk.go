@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"time"
+
+	tfTypes "github.com/colortokens/terraform-provider-xshield/internal/provider/types"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (r *UserGroupHitsDataSourceModel) RefreshFromSharedUserGroupHits(resp []shared.UserGroupHit, since time.Time) {
+	var lastHit time.Time
+	peers := map[string]bool{}
+
+	r.Hits = []tfTypes.UserGroupHit{}
+	for _, hitsItem := range resp {
+		observed, ok := parseHitTimestamp(hitsItem.LastHitObserved)
+		if ok && !since.IsZero() && observed.Before(since) {
+			continue
+		}
+		if ok && observed.After(lastHit) {
+			lastHit = observed
+		}
+		if hitsItem.PeerAssetID != nil && *hitsItem.PeerAssetID != "" {
+			peers[*hitsItem.PeerAssetID] = true
+		}
+
+		var hits1 tfTypes.UserGroupHit
+		hits1.Direction = types.StringPointerValue(hitsItem.Direction)
+		hits1.LastHitObserved = types.StringPointerValue(hitsItem.LastHitObserved)
+		hits1.PeerAssetID = types.StringPointerValue(hitsItem.PeerAssetID)
+		hits1.PeerDomain = types.StringPointerValue(hitsItem.PeerDomain)
+		hits1.PeerIps = make([]types.String, 0, len(hitsItem.PeerIps))
+		for _, v := range hitsItem.PeerIps {
+			hits1.PeerIps = append(hits1.PeerIps, types.StringValue(v))
+		}
+		hits1.PeerNamedNetworkID = types.StringPointerValue(hitsItem.PeerNamedNetworkID)
+		hits1.Port = types.StringPointerValue(hitsItem.Port)
+		hits1.Protocol = types.StringPointerValue(hitsItem.Protocol)
+		if hitsItem.UserGroup != nil {
+			hits1.UserGroupID = types.StringPointerValue(hitsItem.UserGroup.AssetID)
+			hits1.UserGroupName = types.StringValue(hitsItem.UserGroup.AssetName)
+		} else {
+			hits1.UserGroupID = types.StringNull()
+			hits1.UserGroupName = types.StringNull()
+		}
+		hits1.UserName = types.StringPointerValue(hitsItem.UserName)
+		r.Hits = append(r.Hits, hits1)
+	}
+
+	r.HitCount = types.Int64Value(int64(len(r.Hits)))
+	r.LastHitObserved = lastHitValue(lastHit)
+	r.PeerAssetIds = sortedStringValues(peers)
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+)
+
+func TestListUserGroupHitsWalksEveryPage(t *testing.T) {
+	var offsets []int64
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var search shared.SearchInput
+		if err := json.NewDecoder(r.Body).Decode(&search); err != nil {
+			t.Error(err)
+		}
+		offset := *search.Offset
+		offsets = append(offsets, offset)
+
+		// A full first page, then a last page holding a single hit
+		count := *search.Limit
+		if offset > 0 {
+			count = 1
+		}
+		hits := shared.UserGroupHits{}
+		for i := int64(0); i < count; i++ {
+			group := "ug-1"
+			if i%2 == 1 {
+				group = "ug-2"
+			}
+			hits.Items = append(hits.Items, shared.UserGroupHit{
+				UserName:  ptr(fmt.Sprintf("user-%d", offset+i)),
+				UserGroup: &shared.AssetSummary{AssetID: ptr(group)},
+			})
+		}
+		writeJSON(t, w, http.StatusOK, hits)
+	}))
+
+	hits, err := listUserGroupHits(context.Background(), client, "", "ug-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(offsets) != 2 || offsets[0] != 0 || offsets[1] != assetListPageSize {
+		t.Errorf("requested offsets %v, want [0 %d]", offsets, assetListPageSize)
+	}
+	if want := int(assetListPageSize/2) + 1; len(hits) != want {
+		t.Errorf("got %d hits, want the %d hits of ug-1", len(hits), want)
+	}
+	for _, hit := range hits {
+		if *hit.UserGroup.AssetID != "ug-1" {
+			t.Errorf("got hit %s of user group %s", *hit.UserName, *hit.UserGroup.AssetID)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	tfReflect "github.com/colortokens/terraform-provider-xshield/internal/provider/reflect"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -25,8 +26,10 @@ import (
 )
 
 func debugResponse(response *http.Response) string {
-	if v := response.Request.Header.Get("Authorization"); v != "" {
-		response.Request.Header.Set("Authorization", "(sensitive)")
+	for _, header := range sensitiveHttpHeaders {
+		if v := response.Request.Header.Get(header); v != "" {
+			response.Request.Header.Set(header, "(sensitive)")
+		}
 	}
 	dumpReq, err := httputil.DumpRequest(response.Request, true)
 	if err != nil {
@@ -107,13 +110,22 @@ type ProviderHTTPTransportOpts struct {
 
 	// Underlying HTTP transport.
 	Transport http.RoundTripper
+
+	// Maximum number of bytes of a request or response body to log. Longer bodies are
+	// truncated and bodies are not logged at all when it is 0.
+	MaxBodyLength int
+
+	// Secret values, such as proxy credentials, replaced wherever they appear in a log entry.
+	Redact []string
 }
 
 // Note: this is taken as a more minimal/specific version of https://github.com/hashicorp/terraform-plugin-sdk/blob/main/helper/logging/logging_http_transport.go
 func NewProviderHTTPTransport(opts ProviderHTTPTransportOpts) *providerHttpTransport {
 	return &providerHttpTransport{
-		setHeaders: opts.SetHeaders,
-		transport:  opts.Transport,
+		setHeaders:    opts.SetHeaders,
+		transport:     opts.Transport,
+		maxBodyLength: opts.MaxBodyLength,
+		redact:        opts.Redact,
 	}
 }
 
@@ -130,16 +142,25 @@ const (
 	FieldHttpResponseStatusReason = "tf_http_res_status_reason"
 	FieldHttpResponseBody         = "tf_http_res_body"
 	FieldHttpTransactionId        = "tf_http_trans_id"
+	FieldHttpOperationId          = "tf_http_op_id"
 )
 
+// Headers whose values are never logged
+var sensitiveHttpHeaders = []string{"Authorization", "Proxy-Authorization", "X-Content-Sha256"}
+
 type providerHttpTransport struct {
-	setHeaders map[string]string
-	transport  http.RoundTripper
+	setHeaders    map[string]string
+	transport     http.RoundTripper
+	maxBodyLength int
+	redact        []string
 }
 
 func (t *providerHttpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	ctx := req.Context()
 	ctx = t.addTransactionIdField(ctx)
+	if operationId := sdk.OperationID(ctx); operationId != "" {
+		ctx = tflog.SetField(ctx, FieldHttpOperationId, operationId)
+	}
 
 	// Set globally defined HTTP headers in the request
 	t.setRequestHeaders(req)
@@ -151,6 +172,7 @@ func (t *providerHttpTransport) RoundTrip(req *http.Request) (*http.Response, er
 			"error": err,
 		}}...)
 	} else {
+		t.sanitizeFields(fields, FieldHttpRequestBody)
 		tflog.Debug(ctx, "Sending HTTP Request", []map[string]interface{}{fields}...)
 	}
 
@@ -167,6 +189,7 @@ func (t *providerHttpTransport) RoundTrip(req *http.Request) (*http.Response, er
 			"error": err,
 		}}...)
 	} else {
+		t.sanitizeFields(fields, FieldHttpResponseBody)
 		tflog.Debug(ctx, "Received HTTP Response", []map[string]interface{}{fields}...)
 	}
 
@@ -184,6 +207,53 @@ func (t *providerHttpTransport) addTransactionIdField(ctx context.Context) conte
 	return tflog.SetField(ctx, FieldHttpTransactionId, tId)
 }
 
+// Redacts sensitive headers and secret values, and truncates the body field to the maximum
+// body length.
+func (t *providerHttpTransport) sanitizeFields(fields map[string]interface{}, bodyField string) {
+	for _, header := range sensitiveHttpHeaders {
+		if _, ok := fields[header]; ok {
+			fields[header] = "(sensitive)"
+		}
+	}
+
+	for k, v := range fields {
+		switch v := v.(type) {
+		case string:
+			fields[k] = t.redactSecrets(v)
+		case []string:
+			redacted := make([]string, len(v))
+			for i := range v {
+				redacted[i] = t.redactSecrets(v[i])
+			}
+			fields[k] = redacted
+		}
+	}
+
+	// Truncate after redacting so that no part of a secret is left at the cut
+	if body, ok := fields[bodyField].(string); ok {
+		fields[bodyField] = truncateBodyForLogging(body, t.maxBodyLength)
+	}
+}
+
+func (t *providerHttpTransport) redactSecrets(value string) string {
+	for _, secret := range t.redact {
+		if secret != "" {
+			value = strings.ReplaceAll(value, secret, "(sensitive)")
+		}
+	}
+	return value
+}
+
+func truncateBodyForLogging(body string, maxLength int) string {
+	if len(body) <= maxLength {
+		return body
+	}
+	if maxLength <= 0 {
+		return fmt.Sprintf("(%d bytes not logged)", len(body))
+	}
+	return fmt.Sprintf("%s... (%d more bytes truncated)", body[:maxLength], len(body)-maxLength)
+}
+
 // Sets globally defined HTTP headers in the request.
 func (t *providerHttpTransport) setRequestHeaders(req *http.Request) {
 	for name, value := range t.setHeaders {
@@ -245,10 +315,6 @@ func fieldHeadersFromRequestReader(reader *textproto.Reader, fields map[string]i
 			fields[k] = v
 		}
 	}
-	if _, ok := fields["Authorization"]; ok {
-		fields["Authorization"] = "(sensitive)"
-	}
-
 	return nil
 }
 
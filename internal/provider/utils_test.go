@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestProviderHTTPTransportKeepsSecretsOutOfLogs(t *testing.T) {
+	const proxyCreds = "alice:s3cr3t!pw"
+	secrets := []string{
+		"Signature c2lnbmF0dXJlLW9mLXRoZS1yZXF1ZXN0",
+		"Basic " + base64.StdEncoding.EncodeToString([]byte(proxyCreds)),
+		"ZGlnZXN0LW9mLXRoZS1ib2R5",
+		proxyCreds,
+		"s3cr3t!pw",
+		url.QueryEscape("s3cr3t!pw"),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The response echoes the password, then pads the body past the logged length
+		_, _ = io.WriteString(w, `{"password": "s3cr3t!pw", "padding": "`+strings.Repeat("x", 200)+`"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	transport := NewProviderHTTPTransport(ProviderHTTPTransportOpts{
+		Transport:     http.DefaultTransport,
+		MaxBodyLength: 64,
+		Redact:        proxySecrets(proxyCreds),
+	})
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	body := `{"note": "s3cr3t!pw", "padding": "` + strings.Repeat("y", 200) + `"}`
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/api/assets?token="+url.QueryEscape("s3cr3t!pw"), strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", secrets[0])
+	req.Header.Set("Proxy-Authorization", secrets[1])
+	req.Header.Set("X-Content-Sha256", secrets[2])
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	logged := logs.String()
+	if !strings.Contains(logged, "Sending HTTP Request") || !strings.Contains(logged, "Received HTTP Response") {
+		t.Fatalf("request and response not logged: %s", logged)
+	}
+	for _, secret := range secrets {
+		if strings.Contains(logged, secret) {
+			t.Errorf("logs contain the secret %q: %s", secret, logged)
+		}
+	}
+	for _, padding := range []string{strings.Repeat("x", 100), strings.Repeat("y", 100)} {
+		if strings.Contains(logged, padding) {
+			t.Errorf("logs contain a body longer than the limit: %s", logged)
+		}
+	}
+	if !strings.Contains(logged, "more bytes truncated") {
+		t.Errorf("logs do not mark truncated bodies: %s", logged)
+	}
+}
+
+func TestTruncateBodyForLogging(t *testing.T) {
+	tests := []struct {
+		body      string
+		maxLength int
+		want      string
+	}{
+		{body: "short", maxLength: 10, want: "short"},
+		{body: "0123456789", maxLength: 4, want: "0123... (6 more bytes truncated)"},
+		{body: "0123456789", maxLength: 0, want: "(10 bytes not logged)"},
+		{body: "", maxLength: 0, want: ""},
+	}
+	for _, test := range tests {
+		if got := truncateBodyForLogging(test.body, test.maxLength); got != test.want {
+			t.Errorf("truncateBodyForLogging(%q, %d) = %q, want %q", test.body, test.maxLength, got, test.want)
+		}
+	}
+}
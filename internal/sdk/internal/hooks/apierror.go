@@ -0,0 +1,20 @@
+package hooks
+
+import (
+	"net/http"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
+)
+
+// APIErrorHook turns every error response of the API into the typed error of its status,
+// so that all operations report errors the same way whichever statuses their specification
+// documents
+type APIErrorHook struct {
+}
+
+func (aeh *APIErrorHook) AfterSuccess(hookCtx AfterSuccessContext, res *http.Response) (*http.Response, error) {
+	if res == nil || res.StatusCode < http.StatusBadRequest {
+		return res, nil
+	}
+	return res, errors.NewAPIError(res)
+}
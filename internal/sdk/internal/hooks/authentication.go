@@ -57,24 +57,26 @@ func (ah *AuthenticationHook) signRequest(req *http.Request, signingMaterial sha
 		fmt.Sprintf("host: %s", req.Host),
 		fmt.Sprintf("x-content-sha256: %s", req.Header.Get("x-content-sha256"))}
 
-	signature, err := calculateSignature(signatureMaterial, signingMaterial)
+	// The key and the IDs come from one snapshot so that a credential refresh between them
+	// cannot produce a keyId that does not match the signature
+	material, err := shared.LoadSigningMaterial(signingMaterial)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf(`Signature version="1",keyId="%s::%s::%s",algorithm="rsa-sha256",headers="%s",signature="%s"`, signingMaterial.TenancyId(), signingMaterial.PrincipalId(), signingMaterial.FingerPrint(), strings.Join(signatureHeaders, " "), signature))
+	signature, err := calculateSignature(signatureMaterial, material.Key)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf(`Signature version="1",keyId="%s::%s::%s",algorithm="rsa-sha256",headers="%s",signature="%s"`, material.TenancyId, material.PrincipalId, material.FingerPrint, strings.Join(signatureHeaders, " "), signature))
 	req.Header.Set("date", date)
 	req.Header.Set("host", req.Host)
 
 	return nil
 }
 
-func calculateSignature(signatureMaterial []string, signingMaterial shared.ConfigurationProvider) (string, error) {
-	privateKey, err := signingMaterial.KeyLoader().LoadKey()
-	if err != nil {
-		return "", err
-	}
-
+func calculateSignature(signatureMaterial []string, privateKey *rsa.PrivateKey) (string, error) {
 	hash := sha256.New()
 	hash.Write([]byte(strings.Join(signatureMaterial, "\n")))
 	hashed := hash.Sum(nil)
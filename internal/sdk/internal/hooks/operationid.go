@@ -0,0 +1,27 @@
+package hooks
+
+import (
+	"context"
+	"net/http"
+)
+
+type operationIDKey struct{}
+
+// OperationIDHook records the operation ID in the context of every request, so that the HTTP
+// client can tell which SDK operation a request was sent for
+type OperationIDHook struct {
+}
+
+func (oh *OperationIDHook) BeforeRequest(hookCtx BeforeRequestContext, req *http.Request) (*http.Request, error) {
+	// Retries run the hooks again on the same request
+	if OperationID(req.Context()) == hookCtx.OperationID {
+		return req, nil
+	}
+	return req.WithContext(context.WithValue(req.Context(), operationIDKey{}, hookCtx.OperationID)), nil
+}
+
+// OperationID returns the operation ID recorded by OperationIDHook, empty when there is none
+func OperationID(ctx context.Context) string {
+	operationID, _ := ctx.Value(operationIDKey{}).(string)
+	return operationID
+}
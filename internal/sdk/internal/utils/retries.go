@@ -11,6 +11,7 @@ import (
 	"math/rand"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +28,11 @@ type Retries struct {
 	StatusCodes []string
 }
 
+// rateLimitedStatusCodes are retried for every operation, whatever its StatusCodes. The
+// server sends them when a client exceeds the request rate of the tenant, and they carry a
+// Retry-After header telling how long to wait.
+var rateLimitedStatusCodes = []string{"429"}
+
 func Retry(ctx context.Context, r Retries, operation func() (*http.Response, error)) (*http.Response, error) {
 	switch r.Config.Strategy {
 	case "backoff":
@@ -35,6 +41,7 @@ func Retry(ctx context.Context, r Retries, operation func() (*http.Response, err
 		}
 
 		var resp *http.Response
+		statusCodes := slices.Concat(r.StatusCodes, rateLimitedStatusCodes)
 
 		err := retryWithBackoff(ctx, r.Config.Backoff, func() error {
 			if resp != nil {
@@ -63,7 +70,7 @@ func Retry(ctx context.Context, r Retries, operation func() (*http.Response, err
 				return fmt.Errorf("no response")
 			}
 
-			for _, code := range r.StatusCodes {
+			for _, code := range statusCodes {
 				if strings.Contains(strings.ToUpper(code), "X") {
 					codeRange, err := strconv.Atoi(code[:1])
 					if err != nil {
@@ -118,6 +125,8 @@ func retryWithBackoff(ctx context.Context, s *retry.BackoffStrategy, operation f
 	}()
 
 	for {
+		next = 0
+
 		err = operation()
 		if err == nil {
 			return nil
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/retry"
+)
+
+// rateLimitedServer answers 429 with retryAfter, if any, to the first limited requests and
+// 200 afterwards. It returns the number of requests received so far.
+func rateLimitedServer(t *testing.T, limited int, retryAfter func() string) (*httptest.Server, *int) {
+	t.Helper()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= limited {
+			if retryAfter != nil {
+				w.Header().Set("Retry-After", retryAfter())
+			}
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// retryGet sends a GET to server through Retry with a fast backoff retrying statusCodes
+func retryGet(t *testing.T, server *httptest.Server, statusCodes ...string) *http.Response {
+	t.Helper()
+
+	retries := Retries{
+		Config: &retry.Config{
+			Strategy: "backoff",
+			Backoff: &retry.BackoffStrategy{
+				InitialInterval: 1,
+				MaxInterval:     10,
+				Exponent:        1.5,
+				MaxElapsedTime:  10000,
+			},
+		},
+		StatusCodes: statusCodes,
+	}
+	res, err := Retry(context.Background(), retries, func() (*http.Response, error) {
+		return server.Client().Get(server.URL)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+func TestRetryAlwaysRetriesRateLimited(t *testing.T) {
+	server, requests := rateLimitedServer(t, 2, nil)
+
+	res := retryGet(t, server, "5XX")
+	if res.StatusCode != http.StatusOK || *requests != 3 {
+		t.Errorf("got %d after %d requests, want 200 after 3", res.StatusCode, *requests)
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	server, requests := rateLimitedServer(t, 1, func() string { return "1" })
+
+	start := time.Now()
+	res := retryGet(t, server)
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("retried after %s, want the 1s of Retry-After", elapsed)
+	}
+	if res.StatusCode != http.StatusOK || *requests != 2 {
+		t.Errorf("got %d after %d requests, want 200 after 2", res.StatusCode, *requests)
+	}
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	server, requests := rateLimitedServer(t, 1, func() string {
+		return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat)
+	})
+
+	start := time.Now()
+	res := retryGet(t, server)
+	// HTTP dates have a precision of a second
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("retried after %s, want the date of Retry-After", elapsed)
+	}
+	if res.StatusCode != http.StatusOK || *requests != 2 {
+		t.Errorf("got %d after %d requests, want 200 after 2", res.StatusCode, *requests)
+	}
+}
@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+)
+
+// Response headers carrying the ID the API assigned to a request
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Trace-Id"}
+
+// APIError is returned by every operation when the API responds with an error status.
+// Responses with a well known status are returned as one of the more specific errors below,
+// each of which unwraps to its APIError.
+type APIError struct {
+	StatusCode int
+	// RequestID is the ID the API assigned to the request, to quote in support cases. It is
+	// empty when the API did not return one.
+	RequestID string
+	// ErrorResponse is the error document returned by the API, nil when the body is not one
+	ErrorResponse *shared.ErrorResponse
+	Body          string
+	RawResponse   *http.Response
+}
+
+var _ error = &APIError{}
+
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("API error: Status %d", e.StatusCode)
+	if e.ErrorResponse != nil && e.ErrorResponse.Message != nil && *e.ErrorResponse.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, *e.ErrorResponse.Message)
+	}
+	if e.RequestID != "" {
+		message = fmt.Sprintf("%s (request ID %s)", message, e.RequestID)
+	}
+	return message
+}
+
+// NotFoundError is returned for 404 Not Found responses
+type NotFoundError struct{ *APIError }
+
+func (e *NotFoundError) Unwrap() error { return e.APIError }
+
+// ConflictError is returned for 409 Conflict responses, such as when a resource is still
+// being changed by a background process
+type ConflictError struct{ *APIError }
+
+func (e *ConflictError) Unwrap() error { return e.APIError }
+
+// UnauthorizedError is returned for 401 Unauthorized and 403 Forbidden responses
+type UnauthorizedError struct{ *APIError }
+
+func (e *UnauthorizedError) Unwrap() error { return e.APIError }
+
+// RateLimitedError is returned for 429 Too Many Requests responses still rate limited after
+// the retries
+type RateLimitedError struct {
+	*APIError
+	// RetryAfter is the delay requested by the Retry-After header, 0 when there is none
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Unwrap() error { return e.APIError }
+
+// ValidationError is returned for 400 Bad Request and 422 Unprocessable Entity responses
+type ValidationError struct{ *APIError }
+
+func (e *ValidationError) Unwrap() error { return e.APIError }
+
+// NewAPIError returns the error for an error response of the API. The body of res is read
+// and replaced so that it can be read again.
+func NewAPIError(res *http.Response) error {
+	apiErr := &APIError{
+		StatusCode:  res.StatusCode,
+		RawResponse: res,
+	}
+	for _, header := range requestIDHeaders {
+		if id := res.Header.Get(header); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+
+	if res.Body != nil {
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		apiErr.Body = string(body)
+
+		var errorResponse shared.ErrorResponse
+		if json.Unmarshal(body, &errorResponse) == nil && (errorResponse.Message != nil || len(errorResponse.Details) > 0) {
+			apiErr.ErrorResponse = &errorResponse
+		}
+	}
+
+	switch res.StatusCode {
+	case http.StatusNotFound:
+		return &NotFoundError{apiErr}
+	case http.StatusConflict:
+		return &ConflictError{apiErr}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &UnauthorizedError{apiErr}
+	case http.StatusTooManyRequests:
+		return &RateLimitedError{APIError: apiErr, RetryAfter: retryAfter(res)}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &ValidationError{apiErr}
+	}
+	return apiErr
+}
+
+func retryAfter(res *http.Response) time.Duration {
+	value := strings.TrimSpace(res.Header.Get("Retry-After"))
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && time.Until(date) > 0 {
+		return time.Until(date)
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+package shared
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// cachingPrivateKeyFromFile parses the private key file once and parses it again only when
+// its modification time or size changes, so a key rotated during a run is picked up
+// without re-reading the file for every request
+type cachingPrivateKeyFromFile struct {
+	loader privateKeyFromFile
+
+	mu      sync.Mutex
+	key     *rsa.PrivateKey
+	modTime time.Time
+	size    int64
+}
+
+// NewCachingPrivateKeyFromFileLoader returns a loader for the key file at path that caches
+// the parsed key until the file changes
+func NewCachingPrivateKeyFromFileLoader(ctx context.Context, path, passphrase string) PrivateKeyLoader {
+	return &cachingPrivateKeyFromFile{loader: privateKeyFromFile{filePath: path, passphrase: passphrase}}
+}
+
+func (c *cachingPrivateKeyFromFile) LoadKey() (*rsa.PrivateKey, error) {
+	if c.loader.filePath == "" {
+		return nil, fmt.Errorf("invalid file path for private key: %s", c.loader.filePath)
+	}
+
+	// Stat before reading so that a change made while reading triggers another reload
+	info, err := os.Stat(c.loader.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key. Path: %s, Error: %v", c.loader.filePath, err.Error())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.key != nil && info.ModTime().Equal(c.modTime) && info.Size() == c.size {
+		return c.key, nil
+	}
+
+	key, err := c.loader.LoadKey()
+	if err != nil {
+		return nil, err
+	}
+
+	c.key, c.modTime, c.size = key, info.ModTime(), info.Size()
+	return key, nil
+}
@@ -0,0 +1,104 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// copyKeyFile copies testdata/rsa.pem to a temporary file and returns its path
+func copyKeyFile(tb testing.TB) string {
+	tb.Helper()
+
+	data, err := os.ReadFile("testdata/rsa.pem")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	path := filepath.Join(tb.TempDir(), "key.pem")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}
+
+func TestCachingPrivateKeyLoaderReloadsOnModTimeChange(t *testing.T) {
+	path := copyKeyFile(t)
+	loader := NewCachingPrivateKeyFromFileLoader(context.Background(), path, "")
+
+	want, err := loader.LoadKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Same size and modification time: the cached key is returned without reading the file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	garbage := bytes.Repeat([]byte{'x'}, len(data))
+	if err := os.WriteFile(path, garbage, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	key, err := loader.LoadKey()
+	if err != nil {
+		t.Fatalf("re-read the file although it did not change: %v", err)
+	}
+	if !key.Equal(want) {
+		t.Error("got another key from the cache")
+	}
+
+	// A new modification time makes the loader read the file again
+	later := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loader.LoadKey(); err == nil {
+		t.Fatal("did not re-read the file after its modification time changed")
+	}
+
+	// Restoring the key is picked up as well
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later = later.Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	key, err = loader.LoadKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.Equal(want) {
+		t.Error("got another key after restoring the file")
+	}
+}
+
+func BenchmarkCachingPrivateKeyLoader(b *testing.B) {
+	path := copyKeyFile(b)
+
+	for _, bench := range []struct {
+		name   string
+		loader PrivateKeyLoader
+	}{
+		{name: "cached", loader: NewCachingPrivateKeyFromFileLoader(context.Background(), path, "")},
+		{name: "uncached", loader: NewPrivateKeyFromFileLoader(context.Background(), path, "")},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := bench.loader.LoadKey(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
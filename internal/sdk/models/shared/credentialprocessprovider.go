@@ -0,0 +1,255 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// credentialProcessTimeout bounds a single run of the credential process
+	credentialProcessTimeout = time.Minute
+	// credentialProcessRefreshMargin is how long before the reported expiry the credential
+	// process is run again
+	credentialProcessRefreshMargin = time.Minute
+	// credentialProcessRetryInterval is how long to wait before running the credential
+	// process again after a failed refresh, while the cached credentials are still valid. It
+	// doubles with each consecutive failure up to credentialProcessMaxRetryInterval.
+	credentialProcessRetryInterval    = 2 * time.Second
+	credentialProcessMaxRetryInterval = 16 * time.Second
+)
+
+// CredentialProcessOutput is the JSON document a credential process writes to stdout
+type CredentialProcessOutput struct {
+	Tenancy     string `json:"tenancy"`
+	User        string `json:"user"`
+	Fingerprint string `json:"fingerprint"`
+	// PEM encoded private key
+	PrivateKey           string `json:"private_key"`
+	PrivateKeyPassphrase string `json:"private_key_passphrase,omitempty"`
+	// Optional RFC3339 time after which the process must be run again. The output is cached
+	// for the lifetime of the provider when it is not set.
+	Expiration *time.Time `json:"expiration,omitempty"`
+}
+
+// CredentialProcessProvider is a ConfigurationProvider and PrivateKeyLoader that obtains the
+// signing material from an external command, such as a secrets manager CLI or an agent
+// backed by an HSM. The output of the command is cached and the command is run again
+// shortly before the expiry it reports.
+type CredentialProcessProvider struct {
+	command []string
+
+	mu       sync.Mutex
+	output   *CredentialProcessOutput
+	key      *rsa.PrivateKey
+	fetched  bool
+	err      error
+	failures int
+	retryAt  time.Time
+}
+
+// NewCredentialProcessProvider returns a provider running command, a command line whose
+// arguments are separated by spaces and may be quoted
+func NewCredentialProcessProvider(command string) (*CredentialProcessProvider, error) {
+	args, err := splitCommandLine(command)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("credential process command is empty")
+	}
+	return &CredentialProcessProvider{command: args}, nil
+}
+
+// TenancyId, PrincipalId and FingerPrint return the IDs of the cached output. They may come
+// from a later run than a key returned earlier by LoadKey, so requests are signed with
+// SigningMaterial instead.
+func (cpp *CredentialProcessProvider) TenancyId() string   { return cpp.cached().Tenancy }
+func (cpp *CredentialProcessProvider) PrincipalId() string { return cpp.cached().User }
+func (cpp *CredentialProcessProvider) FingerPrint() string { return cpp.cached().Fingerprint }
+func (cpp *CredentialProcessProvider) KeyLoader() PrivateKeyLoader {
+	return cpp
+}
+
+// LoadKey returns the private key of SigningMaterial
+func (cpp *CredentialProcessProvider) LoadKey() (*rsa.PrivateKey, error) {
+	material, err := cpp.SigningMaterial()
+	return material.Key, err
+}
+
+// SigningMaterial returns the private key and the IDs from the same run of the credential
+// process, running it first when nothing is cached yet or the cached output is about to
+// expire. When that run fails, the cached output is returned as long as it has not expired,
+// and the process is only run again after a backoff.
+func (cpp *CredentialProcessProvider) SigningMaterial() (SigningMaterial, error) {
+	cpp.mu.Lock()
+	defer cpp.mu.Unlock()
+
+	now := time.Now()
+	if cpp.needsRefresh(now) {
+		cpp.refresh(now)
+	}
+	if cpp.valid(now) {
+		return SigningMaterial{
+			Key:         cpp.key,
+			TenancyId:   cpp.output.Tenancy,
+			PrincipalId: cpp.output.User,
+			FingerPrint: cpp.output.Fingerprint,
+		}, nil
+	}
+	if cpp.err != nil {
+		return SigningMaterial{}, cpp.err
+	}
+	return SigningMaterial{}, fmt.Errorf("credentials returned by credential process %s have expired", cpp.command[0])
+}
+
+// Refresh runs the credential process now and reports any failure
+func (cpp *CredentialProcessProvider) Refresh() error {
+	cpp.mu.Lock()
+	defer cpp.mu.Unlock()
+
+	cpp.refresh(time.Now())
+	return cpp.err
+}
+
+// cached returns the cached output, running the credential process if it never ran
+func (cpp *CredentialProcessProvider) cached() CredentialProcessOutput {
+	cpp.mu.Lock()
+	defer cpp.mu.Unlock()
+
+	if !cpp.fetched {
+		cpp.refresh(time.Now())
+	}
+	if cpp.output == nil {
+		return CredentialProcessOutput{}
+	}
+	return *cpp.output
+}
+
+// valid reports whether the cached output can still be used at now. The caller holds mu.
+func (cpp *CredentialProcessProvider) valid(now time.Time) bool {
+	if cpp.output == nil {
+		return false
+	}
+	return cpp.output.Expiration == nil || now.Before(*cpp.output.Expiration)
+}
+
+// needsRefresh reports whether the credential process must run at now. The caller holds mu.
+func (cpp *CredentialProcessProvider) needsRefresh(now time.Time) bool {
+	switch {
+	case !cpp.fetched:
+		return true
+	case !cpp.valid(now):
+		// Nothing usable is cached, failed runs are retried on the next request
+		return true
+	case cpp.output.Expiration == nil:
+		return false
+	}
+	expiresSoon := !now.Add(credentialProcessRefreshMargin).Before(*cpp.output.Expiration)
+	return expiresSoon && !now.Before(cpp.retryAt)
+}
+
+// refresh runs the credential process and caches its output. A failure keeps the previous
+// output and delays the next attempt. The caller holds mu.
+func (cpp *CredentialProcessProvider) refresh(now time.Time) {
+	cpp.fetched = true
+
+	output, err := cpp.run()
+	if err == nil {
+		var key *rsa.PrivateKey
+		key, err = privateKeyFromBytesWithPassword([]byte(output.PrivateKey), []byte(output.PrivateKeyPassphrase))
+		if err == nil {
+			cpp.output, cpp.key, cpp.err = output, key, nil
+			cpp.failures, cpp.retryAt = 0, time.Time{}
+			return
+		}
+		err = fmt.Errorf("credential process %s returned an unusable private key: %w", cpp.command[0], err)
+	}
+
+	cpp.err = err
+	backoff := min(credentialProcessRetryInterval<<min(cpp.failures, 8), credentialProcessMaxRetryInterval)
+	cpp.failures++
+	cpp.retryAt = now.Add(backoff)
+}
+
+func (cpp *CredentialProcessProvider) run() (*CredentialProcessOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialProcessTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, cpp.command[0], cpp.command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			return nil, fmt.Errorf("credential process %s failed: %v: %s", cpp.command[0], err, detail)
+		}
+		return nil, fmt.Errorf("credential process %s failed: %v", cpp.command[0], err)
+	}
+
+	var output CredentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("credential process %s returned invalid JSON: %w", cpp.command[0], err)
+	}
+
+	var missing []string
+	for _, field := range []struct{ key, value string }{
+		{"tenancy", output.Tenancy},
+		{"user", output.User},
+		{"fingerprint", output.Fingerprint},
+		{"private_key", output.PrivateKey},
+	} {
+		if field.value == "" {
+			missing = append(missing, field.key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("credential process %s output is missing %s", cpp.command[0], strings.Join(missing, ", "))
+	}
+
+	return &output, nil
+}
+
+// splitCommandLine splits a command line into arguments separated by white space. Single
+// and double quotes group arguments containing white space.
+func splitCommandLine(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	for _, r := range command {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in credential process command")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
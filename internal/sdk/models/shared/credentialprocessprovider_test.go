@@ -0,0 +1,176 @@
+package shared
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// stubCredentialProcess is a credential process that prints out<n>.json on its nth run, or
+// fails when there is no such file
+type stubCredentialProcess struct {
+	t   *testing.T
+	dir string
+}
+
+func newStubCredentialProcess(t *testing.T) *stubCredentialProcess {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the stub credential process is a shell script")
+	}
+
+	dir := t.TempDir()
+	script := `#!/bin/sh
+echo run >> "` + dir + `/runs"
+n=$(wc -l < "` + dir + `/runs" | tr -d ' ')
+if [ -f "` + dir + `/out$n.json" ]; then
+	cat "` + dir + `/out$n.json"
+else
+	echo "no credentials for run $n" >&2
+	exit 1
+fi
+`
+	if err := os.WriteFile(filepath.Join(dir, "process.sh"), []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return &stubCredentialProcess{t: t, dir: dir}
+}
+
+func (s *stubCredentialProcess) provider() *CredentialProcessProvider {
+	s.t.Helper()
+
+	cpp, err := NewCredentialProcessProvider("/bin/sh '" + filepath.Join(s.dir, "process.sh") + "'")
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return cpp
+}
+
+// output sets what the process prints on its nth run and returns the key it holds
+func (s *stubCredentialProcess) output(n int, tenancy string, expiration time.Time) *rsa.PrivateKey {
+	s.t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	fingerprint, err := KeyFingerprint(&key.PublicKey)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+
+	data, err := json.Marshal(CredentialProcessOutput{
+		Tenancy:     tenancy,
+		User:        "user",
+		Fingerprint: fingerprint,
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		Expiration:  &expiration,
+	})
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, fmt.Sprintf("out%d.json", n)), data, 0o600); err != nil {
+		s.t.Fatal(err)
+	}
+	return key
+}
+
+// runs returns how many times the process ran
+func (s *stubCredentialProcess) runs() int {
+	s.t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(s.dir, "runs"))
+	if os.IsNotExist(err) {
+		return 0
+	}
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return bytes.Count(data, []byte("\n"))
+}
+
+func TestCredentialProcessProviderSigningMaterial(t *testing.T) {
+	stub := newStubCredentialProcess(t)
+	// The first output expires within the refresh margin, so the next request runs the
+	// process again
+	first := stub.output(1, "tenancy-1", time.Now().Add(credentialProcessRefreshMargin/2))
+	second := stub.output(2, "tenancy-2", time.Now().Add(time.Hour))
+	cpp := stub.provider()
+
+	for i, want := range []struct {
+		key     *rsa.PrivateKey
+		tenancy string
+		runs    int
+	}{
+		{key: first, tenancy: "tenancy-1", runs: 1},
+		{key: second, tenancy: "tenancy-2", runs: 2},
+		{key: second, tenancy: "tenancy-2", runs: 2},
+	} {
+		material, err := cpp.SigningMaterial()
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		if !material.Key.Equal(want.key) || material.TenancyId != want.tenancy {
+			t.Errorf("request %d: got the key and tenancy %s of another run, want %s", i, material.TenancyId, want.tenancy)
+		}
+		fingerprint, err := KeyFingerprint(&material.Key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fingerprint != material.FingerPrint {
+			t.Errorf("request %d: fingerprint %s does not belong to the key, want %s", i, material.FingerPrint, fingerprint)
+		}
+		if runs := stub.runs(); runs != want.runs {
+			t.Errorf("request %d: process ran %d times, want %d", i, runs, want.runs)
+		}
+	}
+}
+
+func TestCredentialProcessProviderKeepsValidCredentialsWhileRefreshFails(t *testing.T) {
+	stub := newStubCredentialProcess(t)
+	key := stub.output(1, "tenancy", time.Now().Add(credentialProcessRefreshMargin/2))
+	cpp := stub.provider()
+
+	for i := 0; i < 5; i++ {
+		material, err := cpp.SigningMaterial()
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		if !material.Key.Equal(key) {
+			t.Errorf("request %d: got another key", i)
+		}
+	}
+	// The second request refreshes and fails, the next ones wait for the backoff
+	if runs := stub.runs(); runs != 2 {
+		t.Errorf("process ran %d times, want 2", runs)
+	}
+}
+
+func TestCredentialProcessProviderFailsWithoutValidCredentials(t *testing.T) {
+	stub := newStubCredentialProcess(t)
+	stub.output(1, "tenancy", time.Now().Add(-time.Minute))
+	cpp := stub.provider()
+
+	for i := 0; i < 2; i++ {
+		if _, err := cpp.SigningMaterial(); err == nil {
+			t.Fatalf("request %d: signed with expired credentials", i)
+		}
+	}
+	// Nothing usable is cached, so every request runs the process
+	if runs := stub.runs(); runs != 2 {
+		t.Errorf("process ran %d times, want 2", runs)
+	}
+}
@@ -0,0 +1,154 @@
+package shared
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+
+	errBadPassphrase = errors.New("failed to decrypt private key: wrong passphrase or corrupt key")
+)
+
+// encryptedPrivateKeyInfo is the PKCS#8 EncryptedPrivateKeyInfo structure found in
+// "ENCRYPTED PRIVATE KEY" PEM blocks
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decryptPKCS8PrivateKey decrypts a PKCS#8 EncryptedPrivateKeyInfo protected with PBES2,
+// the scheme used by OpenSSL and most key management tools, and returns the DER encoded
+// PrivateKeyInfo
+func decryptPKCS8PrivateKey(der, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted private key: %w", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported private key encryption %v, only PBES2 is supported", info.Algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse PBES2 parameters: %w", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported key derivation function %v, only PBKDF2 is supported", params.KeyDerivationFunc.Algorithm)
+	}
+
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("failed to parse PBKDF2 parameters: %w", err)
+	}
+	if kdf.IterationCount < 1 || len(kdf.Salt) == 0 {
+		return nil, fmt.Errorf("invalid PBKDF2 parameters: %d iterations, %d byte salt", kdf.IterationCount, len(kdf.Salt))
+	}
+
+	prf, err := pbkdf2PRF(kdf.PRF.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyLength int
+	var newCipher func([]byte) (cipher.Block, error)
+	switch scheme := params.EncryptionScheme.Algorithm; {
+	case scheme.Equal(oidAES128CBC):
+		keyLength, newCipher = 16, aes.NewCipher
+	case scheme.Equal(oidAES192CBC):
+		keyLength, newCipher = 24, aes.NewCipher
+	case scheme.Equal(oidAES256CBC):
+		keyLength, newCipher = 32, aes.NewCipher
+	case scheme.Equal(oidDESEDE3CBC):
+		keyLength, newCipher = 24, des.NewTripleDESCipher
+	default:
+		return nil, fmt.Errorf("unsupported private key cipher %v", scheme)
+	}
+	if kdf.KeyLength != 0 && kdf.KeyLength != keyLength {
+		return nil, fmt.Errorf("PBKDF2 key length %d does not match the cipher", kdf.KeyLength)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("failed to parse cipher parameters: %w", err)
+	}
+
+	block, err := newCipher(pbkdf2.Key(passphrase, kdf.Salt, kdf.IterationCount, keyLength, prf))
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() || len(info.EncryptedData) == 0 || len(info.EncryptedData)%block.BlockSize() != 0 {
+		return nil, errBadPassphrase
+	}
+
+	decrypted := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, info.EncryptedData)
+
+	return removePKCS7Padding(decrypted, block.BlockSize())
+}
+
+func pbkdf2PRF(oid asn1.ObjectIdentifier) (func() hash.Hash, error) {
+	switch {
+	case len(oid) == 0, oid.Equal(oidHMACWithSHA1):
+		return sha1.New, nil
+	case oid.Equal(oidHMACWithSHA224):
+		return sha256.New224, nil
+	case oid.Equal(oidHMACWithSHA256):
+		return sha256.New, nil
+	case oid.Equal(oidHMACWithSHA384):
+		return sha512.New384, nil
+	case oid.Equal(oidHMACWithSHA512):
+		return sha512.New, nil
+	}
+	return nil, fmt.Errorf("unsupported PBKDF2 pseudorandom function %v", oid)
+}
+
+// removePKCS7Padding strips the padding of decrypted data. A wrong passphrase almost always
+// yields invalid padding, so it is reported as such.
+func removePKCS7Padding(data []byte, blockSize int) ([]byte, error) {
+	if len(data) == 0 || len(data)%blockSize != 0 {
+		return nil, errBadPassphrase
+	}
+	n := int(data[len(data)-1])
+	if n == 0 || n > blockSize || n > len(data) || !bytes.Equal(data[len(data)-n:], bytes.Repeat([]byte{byte(n)}, n)) {
+		return nil, errBadPassphrase
+	}
+	return data[:len(data)-n], nil
+}
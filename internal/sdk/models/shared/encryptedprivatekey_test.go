@@ -0,0 +1,158 @@
+package shared
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"os"
+	"testing"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// The fixtures were generated with
+//
+//	openssl genrsa -out rsa.pem 2048
+//	openssl pkcs8 -topk8 -v2 aes-256-cbc -v2prf hmacWithSHA1 -in rsa.pem -out rsa_aes256_sha1.pem -passout 'pass:correct horse'
+//	openssl pkcs8 -topk8 -v2 aes-256-cbc -v2prf hmacWithSHA256 -in rsa.pem -out rsa_aes256_sha256.pem -passout 'pass:correct horse'
+const testdataPassphrase = "correct horse"
+
+func readPEM(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDecryptPKCS8PrivateKey(t *testing.T) {
+	want, err := privateKeyFromBytesWithPassword(readPEM(t, "rsa.pem"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"rsa_aes256_sha1.pem", "rsa_aes256_sha256.pem"} {
+		t.Run(name, func(t *testing.T) {
+			key, err := privateKeyFromBytesWithPassword(readPEM(t, name), []byte(testdataPassphrase))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !key.Equal(want) {
+				t.Error("decrypted key differs from the plain key")
+			}
+		})
+	}
+}
+
+func TestDecryptPKCS8PrivateKeyWrongPassphrase(t *testing.T) {
+	for _, name := range []string{"rsa_aes256_sha1.pem", "rsa_aes256_sha256.pem"} {
+		t.Run(name, func(t *testing.T) {
+			_, err := privateKeyFromBytesWithPassword(readPEM(t, name), []byte("wrong horse"))
+			if err == nil {
+				t.Fatal("decrypted the key with a wrong passphrase")
+			}
+		})
+	}
+}
+
+func TestDecryptPKCS8PrivateKeyRequiresPassphrase(t *testing.T) {
+	_, err := privateKeyFromBytesWithPassword(readPEM(t, "rsa_aes256_sha256.pem"), nil)
+	if err == nil {
+		t.Fatal("decrypted the key without a passphrase")
+	}
+}
+
+func TestDecryptPKCS8PrivateKeyBadPadding(t *testing.T) {
+	block, _ := pem.Decode(readPEM(t, "rsa_aes256_sha256.pem"))
+
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(block.Bytes, &info); err != nil {
+		t.Fatal(err)
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		t.Fatal(err)
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		t.Fatal(err)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		t.Fatal(err)
+	}
+	prf, err := pbkdf2PRF(kdf.PRF.Algorithm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-encrypt the key with the right passphrase but a last block holding invalid padding
+	aesBlock, err := aes.NewCipher(pbkdf2.Key([]byte(testdataPassphrase), kdf.Salt, kdf.IterationCount, 32, prf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(aesBlock, iv).CryptBlocks(plain, info.EncryptedData)
+	plain[len(plain)-1] = 0
+	cipher.NewCBCEncrypter(aesBlock, iv).CryptBlocks(info.EncryptedData, plain)
+	der, err := asn1.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = decryptPKCS8PrivateKey(der, []byte(testdataPassphrase))
+	if !errors.Is(err, errBadPassphrase) {
+		t.Fatalf("err = %v, want %v", err, errBadPassphrase)
+	}
+}
+
+func TestRemovePKCS7Padding(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []byte
+		wantErr bool
+	}{
+		{name: "one byte", data: append(bytes.Repeat([]byte{'a'}, 15), 1), want: bytes.Repeat([]byte{'a'}, 15)},
+		{name: "full block", data: append([]byte("0123456789abcdef"), bytes.Repeat([]byte{16}, 16)...), want: []byte("0123456789abcdef")},
+		{name: "zero", data: append(bytes.Repeat([]byte{'a'}, 15), 0), wantErr: true},
+		{name: "longer than a block", data: bytes.Repeat([]byte{17}, 32), wantErr: true},
+		{name: "inconsistent", data: append(bytes.Repeat([]byte{'a'}, 13), 3, 2, 3), wantErr: true},
+		{name: "partial block", data: []byte{1, 1, 1}, wantErr: true},
+		{name: "empty", data: nil, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := removePKCS7Padding(test.data, 16)
+			if test.wantErr {
+				if !errors.Is(err, errBadPassphrase) {
+					t.Fatalf("err = %v, want %v", err, errBadPassphrase)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, test.want) {
+				t.Errorf("removePKCS7Padding() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecryptPKCS8PrivateKeyParsesAsPKCS8(t *testing.T) {
+	block, _ := pem.Decode(readPEM(t, "rsa_aes256_sha1.pem"))
+	der, err := decryptPKCS8PrivateKey(block.Bytes, []byte(testdataPassphrase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(der); err != nil {
+		t.Errorf("decrypted data is not a PKCS#8 key: %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package shared
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProfile is the profile read from the configuration file when none is named
+const DefaultProfile = "DEFAULT"
+
+// ErrProfileNotFound is returned when the configuration file has no section for a profile
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Keys recognized in a configuration file profile
+const (
+	ProfileKeyTenancy           = "tenancy"
+	ProfileKeyUser              = "user"
+	ProfileKeyFingerprint       = "fingerprint"
+	ProfileKeyKeyFile           = "key_file"
+	ProfileKeyPassPhrase        = "pass_phrase"
+	ProfileKeyRegion            = "region"
+	ProfileKeyServerURL         = "server_url"
+	ProfileKeyProxy             = "proxy"
+	ProfileKeyProxyCreds        = "proxy_creds"
+	ProfileKeyCredentialProcess = "credential_process"
+)
+
+// DefaultConfigFilePath returns the location of the configuration file, ~/.xshield/config
+func DefaultConfigFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the home directory: %w", err)
+	}
+	return filepath.Join(home, ".xshield", "config"), nil
+}
+
+// FileConfigProvider is a ConfigurationProvider reading one profile of an INI style
+// configuration file such as
+//
+//	[DEFAULT]
+//	region = iad
+//
+//	[prod]
+//	tenancy = ...
+//	user = ...
+//	fingerprint = ...
+//	key_file = ~/.xshield/prod.pem
+//
+// Keys missing from a profile are inherited from the DEFAULT profile.
+type FileConfigProvider struct {
+	path    string
+	profile string
+	values  map[string]string
+	loader  PrivateKeyLoader
+}
+
+// NewFileConfigProvider reads profile from the configuration file at path
+func NewFileConfigProvider(path, profile string) (FileConfigProvider, error) {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	sections, err := readConfigFile(path)
+	if err != nil {
+		return FileConfigProvider{}, err
+	}
+
+	values, ok := sections[profile]
+	if !ok {
+		return FileConfigProvider{}, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, profile, path)
+	}
+	if profile != DefaultProfile {
+		for key, value := range sections[DefaultProfile] {
+			if _, ok := values[key]; !ok {
+				values[key] = value
+			}
+		}
+	}
+
+	fcp := FileConfigProvider{path: path, profile: profile, values: values}
+	fcp.loader = NewCachingPrivateKeyFromFileLoader(context.Background(), fcp.KeyFile(), values[ProfileKeyPassPhrase])
+	return fcp, nil
+}
+
+func (fcp FileConfigProvider) TenancyId() string           { return fcp.values[ProfileKeyTenancy] }
+func (fcp FileConfigProvider) PrincipalId() string         { return fcp.values[ProfileKeyUser] }
+func (fcp FileConfigProvider) FingerPrint() string         { return fcp.values[ProfileKeyFingerprint] }
+func (fcp FileConfigProvider) KeyLoader() PrivateKeyLoader { return fcp.loader }
+
+// KeyFile returns the private key location of the profile with a leading ~ expanded
+func (fcp FileConfigProvider) KeyFile() string {
+	return expandHome(fcp.values[ProfileKeyKeyFile])
+}
+
+// Value returns the value of key in the profile, empty when it is not set
+func (fcp FileConfigProvider) Value(key string) string {
+	return fcp.values[key]
+}
+
+// String describes the profile for diagnostics
+func (fcp FileConfigProvider) String() string {
+	return fmt.Sprintf("profile %q in %s", fcp.profile, fcp.path)
+}
+
+// readConfigFile parses an INI style file into its sections
+func readConfigFile(path string) (map[string]map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file. Path: %s, Error: %v", path, err.Error())
+	}
+	defer f.Close()
+
+	sections := map[string]map[string]string{}
+	var section map[string]string
+
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if sections[name] == nil {
+				sections[name] = map[string]string{}
+			}
+			section = sections[name]
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, lineNumber)
+		}
+		if section == nil {
+			return nil, fmt.Errorf("%s:%d: key outside of a [profile] section", path, lineNumber)
+		}
+		section[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read configuration file. Path: %s, Error: %v", path, err.Error())
+	}
+
+	return sections, nil
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,30 @@
+package shared
+
+import (
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"strings"
+)
+
+// KeyFingerprint returns the fingerprint of an API key the way the console shows it: the
+// MD5 digest of the DER encoded public key as colon separated lowercase hex pairs
+func KeyFingerprint(key *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	digest := md5.Sum(der)
+	pairs := make([]string, len(digest))
+	for i, b := range digest {
+		pairs[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(pairs, ":"), nil
+}
+
+// FingerprintsEqual compares two fingerprints ignoring case and surrounding white space
+func FingerprintsEqual(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+func TestKeyFingerprint(t *testing.T) {
+	key, err := privateKeyFromBytesWithPassword(readPEM(t, "rsa.pem"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := KeyFingerprint(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// openssl pkey -in rsa.pem -pubout -outform DER | openssl md5 -c
+	if want := "30:30:24:48:7d:de:aa:93:65:a9:61:01:9e:0d:0f:c1"; got != want {
+		t.Errorf("KeyFingerprint() = %s, want %s", got, want)
+	}
+}
+
+func TestFingerprintsEqual(t *testing.T) {
+	if !FingerprintsEqual("30:30:24:48:7D:DE:AA:93:65:A9:61:01:9E:0D:0F:C1\n", "30:30:24:48:7d:de:aa:93:65:a9:61:01:9e:0d:0f:c1") {
+		t.Error("fingerprints differing in case and white space are not equal")
+	}
+	if FingerprintsEqual("30:30:24:48:7d:de:aa:93:65:a9:61:01:9e:0d:0f:c1", "30:30:24:48:7d:de:aa:93:65:a9:61:01:9e:0d:0f:c2") {
+		t.Error("different fingerprints are equal")
+	}
+}
+
+func TestPrivateKeyRejectsNonRSAPKCS8(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = privateKeyFromBytesWithPassword(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil)
+	if err == nil || !strings.Contains(err.Error(), "only RSA keys are supported") {
+		t.Errorf("err = %v, want the key type to be rejected", err)
+	}
+}
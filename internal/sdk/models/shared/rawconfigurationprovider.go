@@ -7,6 +7,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"os"
+	"sync"
 )
 
 type ConfigurationProvider interface {
@@ -20,6 +21,39 @@ type PrivateKeyLoader interface {
 	LoadKey() (*rsa.PrivateKey, error)
 }
 
+// SigningMaterial is a private key together with the IDs of the API key it belongs to
+type SigningMaterial struct {
+	Key         *rsa.PrivateKey
+	TenancyId   string
+	PrincipalId string
+	FingerPrint string
+}
+
+// SigningMaterialProvider is implemented by configuration providers whose key and IDs can
+// change at run time. SigningMaterial returns them from a single snapshot.
+type SigningMaterialProvider interface {
+	SigningMaterial() (SigningMaterial, error)
+}
+
+// LoadSigningMaterial returns the key and IDs of config, from a single snapshot when config
+// is a SigningMaterialProvider
+func LoadSigningMaterial(config ConfigurationProvider) (SigningMaterial, error) {
+	if provider, ok := config.(SigningMaterialProvider); ok {
+		return provider.SigningMaterial()
+	}
+
+	key, err := config.KeyLoader().LoadKey()
+	if err != nil {
+		return SigningMaterial{}, err
+	}
+	return SigningMaterial{
+		Key:         key,
+		TenancyId:   config.TenancyId(),
+		PrincipalId: config.PrincipalId(),
+		FingerPrint: config.FingerPrint(),
+	}, nil
+}
+
 type privateKeyFromFile struct {
 	filePath   string
 	passphrase string
@@ -43,37 +77,63 @@ func (pkff privateKeyFromFile) LoadKey() (*rsa.PrivateKey, error) {
 	return privateKeyFromBytesWithPassword(priv, []byte(pkff.passphrase))
 }
 
+// privateKeyFromByteSlice parses its content once, on the first call to LoadKey
 type privateKeyFromByteSlice struct {
-	content []byte
+	content    []byte
+	passphrase string
+
+	once sync.Once
+	key  *rsa.PrivateKey
+	err  error
 }
 
 func NewPrivateKeyFromByteSlice(content string) PrivateKeyLoader {
-	return privateKeyFromByteSlice{content: []byte(content)}
+	return &privateKeyFromByteSlice{content: []byte(content)}
 }
 
-func (pkfs privateKeyFromByteSlice) LoadKey() (*rsa.PrivateKey, error) {
+// NewPrivateKeyFromByteSliceWithPassphrase returns a loader for PEM content that may be
+// encrypted with passphrase
+func NewPrivateKeyFromByteSliceWithPassphrase(content, passphrase string) PrivateKeyLoader {
+	return &privateKeyFromByteSlice{content: []byte(content), passphrase: passphrase}
+}
+
+func (pkfs *privateKeyFromByteSlice) LoadKey() (*rsa.PrivateKey, error) {
 
 	if len(pkfs.content) == 0 {
 		return nil, fmt.Errorf("byte slice empty. No key content available")
 	}
 
-	return privateKeyFromBytesWithPassword(pkfs.content, []byte(""))
+	pkfs.once.Do(func() {
+		pkfs.key, pkfs.err = privateKeyFromBytesWithPassword(pkfs.content, []byte(pkfs.passphrase))
+	})
+	return pkfs.key, pkfs.err
 }
 
-func privateKeyFromBytesWithPassword(pemData, _ []byte) (key *rsa.PrivateKey, e error) {
+func privateKeyFromBytesWithPassword(pemData, password []byte) (key *rsa.PrivateKey, e error) {
 	if pemBlock, _ := pem.Decode(pemData); pemBlock != nil {
-		// decrypted := pemBlock.Bytes
-		// if x509.IsEncryptedPEMBlock(pemBlock) {
-		// 	if password == nil {
-		// 		e = fmt.Errorf("private key password is required for encrypted private keys")
-		// 		return
-		// 	}
-		// 	if decrypted, e = x509.DecryptPEMBlock(pemBlock, password); e != nil {
-		// 		return
-		// 	}
-		// }
-
-		key, e = parsePKCSPrivateKey(pemBlock.Bytes)
+		decrypted := pemBlock.Bytes
+		switch {
+		case pemBlock.Type == "ENCRYPTED PRIVATE KEY":
+			if len(password) == 0 {
+				e = fmt.Errorf("private key password is required for encrypted private keys")
+				return
+			}
+			if decrypted, e = decryptPKCS8PrivateKey(pemBlock.Bytes, password); e != nil {
+				return
+			}
+		//nolint:staticcheck // legacy encrypted PEM is insecure but still produced by older tooling
+		case x509.IsEncryptedPEMBlock(pemBlock):
+			if len(password) == 0 {
+				e = fmt.Errorf("private key password is required for encrypted private keys")
+				return
+			}
+			//nolint:staticcheck // see above
+			if decrypted, e = x509.DecryptPEMBlock(pemBlock, password); e != nil {
+				return
+			}
+		}
+
+		key, e = parsePKCSPrivateKey(decrypted)
 
 	} else {
 		e = fmt.Errorf("PEM data was not found in buffer")
@@ -91,7 +151,7 @@ func parsePKCSPrivateKey(decryptedKey []byte) (*rsa.PrivateKey, error) {
 		case *rsa.PrivateKey:
 			return key, nil
 		default:
-			return nil, fmt.Errorf("unsupportesd private key type in PKCS8 wrapping")
+			return nil, fmt.Errorf("unsupported private key type %T in PKCS8 wrapping, only RSA keys are supported", key)
 		}
 	}
 	return nil, fmt.Errorf("failed to parse private key")
@@ -114,7 +174,18 @@ func NewRawConfigProvider(tenancyId, userId, fingerprint, keyLocation string) Ra
 		tenantId:    tenancyId,
 		principalId: userId,
 		fingerprint: fingerprint,
-		loader:      NewPrivateKeyFromFileLoader(context.Background(), keyLocation, ""),
+		loader:      NewCachingPrivateKeyFromFileLoader(context.Background(), keyLocation, ""),
+	}
+}
+
+// NewConfigProvider returns a configuration provider that signs with the key returned by
+// loader
+func NewConfigProvider(tenancyId, userId, fingerprint string, loader PrivateKeyLoader) RawConfigProvider {
+	return RawConfigProvider{
+		tenantId:    tenancyId,
+		principalId: userId,
+		fingerprint: fingerprint,
+		loader:      loader,
 	}
 }
 
@@ -0,0 +1,365 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
+)
+
+// DefaultPageSize is the number of items requested per page by a Pager created with a page
+// size of 0 or less
+const DefaultPageSize int64 = 100
+
+// Page is one page of the results of a list operation
+type Page[T any] struct {
+	Items []T
+	// Total is the total number of results reported by the API, nil when it reported none
+	Total *int64
+}
+
+// PageFunc fetches the page of at most limit results starting at offset
+type PageFunc[T any] func(ctx context.Context, limit, offset int64) (Page[T], error)
+
+// Pager walks every page of the results of a list operation
+type Pager[T any] struct {
+	fetch    PageFunc[T]
+	pageSize int64
+}
+
+// NewPager returns a Pager that fetches pages of pageSize results with fetch
+func NewPager[T any](pageSize int64, fetch PageFunc[T]) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Pager[T]{fetch: fetch, pageSize: pageSize}
+}
+
+// All iterates over the results of every page. Pages are only fetched as the iteration
+// reaches them. A failed request or a cancelled context ends the iteration with the error.
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for offset := int64(0); ; offset += p.pageSize {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			page, err := p.fetch(ctx, p.pageSize, offset)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			// A short page is the last one. So is a page longer than requested: the API
+			// ignored the limit, and the offsets no longer say where the next page starts.
+			if int64(len(page.Items)) != p.pageSize {
+				return
+			}
+			if page.Total != nil && offset+p.pageSize >= *page.Total {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns the results of every page
+func (p *Pager[T]) Collect(ctx context.Context) ([]T, error) {
+	var items []T
+	for item, err := range p.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Find returns the first result for which match returns true. Pages after the one holding
+// it are not fetched.
+func (p *Pager[T]) Find(ctx context.Context, match func(T) bool) (T, bool, error) {
+	var zero T
+	for item, err := range p.All(ctx) {
+		if err != nil {
+			return zero, false, err
+		}
+		if match(item) {
+			return item, true, nil
+		}
+	}
+	return zero, false, nil
+}
+
+// searchPage sets the limit and offset of a search
+func searchPage(search shared.SearchInput, limit, offset int64) shared.SearchInput {
+	search.Limit = Int64(limit)
+	search.Offset = Int64(offset)
+	return search
+}
+
+// paginationConfig returns the pagination of a page of limit results starting at offset
+func paginationConfig(limit, offset int64) *shared.PaginationConfig {
+	return &shared.PaginationConfig{
+		Limit:  Int64(limit),
+		Offset: Int64(offset),
+	}
+}
+
+func unexpectedStatus(statusCode int) error {
+	return fmt.Errorf("unexpected response code %d", statusCode)
+}
+
+// ListAssetsPager returns a Pager over the assets matching request. The limit and offset of
+// request.SearchInput are set by the Pager.
+func (s *Assets) ListAssetsPager(request operations.ListAssetsRequest, pageSize int64, opts ...operations.Option) *Pager[shared.ExtendedAssetSummary] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.ExtendedAssetSummary], error) {
+		request.SearchInput = searchPage(request.SearchInput, limit, offset)
+		res, err := s.ListAssets(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.ExtendedAssetSummary]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.ExtendedAssetSummary]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.ExtendedAssetSummary]{
+			Items: res.AssetSearchResults.GetItems(),
+			Total: res.AssetSearchResults.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListBasicAssetsPager returns a Pager over the assets matching request. The limit and offset
+// of request.SearchInput are set by the Pager.
+func (s *Assets) ListBasicAssetsPager(request operations.ListBasicAssetsRequest, pageSize int64, opts ...operations.Option) *Pager[shared.ExtendedAssetSummary] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.ExtendedAssetSummary], error) {
+		request.SearchInput = searchPage(request.SearchInput, limit, offset)
+		res, err := s.ListBasicAssets(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.ExtendedAssetSummary]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.ExtendedAssetSummary]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.ExtendedAssetSummary]{
+			Items: res.AssetSearchResults.GetItems(),
+			Total: res.AssetSearchResults.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListSecurityPatchesPager returns a Pager over the missing security patches of an asset. The
+// pagination of request.SecurityPatchSearchInput is set by the Pager.
+func (s *Assets) ListSecurityPatchesPager(request operations.ListSecurityPatchesRequest, pageSize int64, opts ...operations.Option) *Pager[shared.DevAzureComColortokensAssetPkgSecurityPatch] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.DevAzureComColortokensAssetPkgSecurityPatch], error) {
+		request.SecurityPatchSearchInput.Pagination = paginationConfig(limit, offset)
+		res, err := s.ListSecurityPatches(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.DevAzureComColortokensAssetPkgSecurityPatch]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.DevAzureComColortokensAssetPkgSecurityPatch]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.DevAzureComColortokensAssetPkgSecurityPatch]{
+			Items: res.SecurityPatchesResults.GetItems(),
+			Total: res.SecurityPatchesResults.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListVulnerabilitiesPager returns a Pager over the CVEs of an asset. The pagination of
+// request.VulnerabilitiesRequestData is set by the Pager.
+func (s *Assets) ListVulnerabilitiesPager(request operations.ListVulnerabilitiesRequest, pageSize int64, opts ...operations.Option) *Pager[shared.CVEData] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.CVEData], error) {
+		request.VulnerabilitiesRequestData.Pagination = paginationConfig(limit, offset)
+		res, err := s.ListVulnerabilities(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.CVEData]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.CVEData]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.CVEData]{
+			Items: res.CVEDataResults.GetItems(),
+			Total: res.CVEDataResults.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListVulnerablePackagesPager returns a Pager over the vulnerable packages of an asset. The
+// request.PaginationConfig is set by the Pager.
+func (s *Assets) ListVulnerablePackagesPager(request operations.ListVulnerablePackagesRequest, pageSize int64, opts ...operations.Option) *Pager[shared.VulnerablePackageData] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.VulnerablePackageData], error) {
+		request.PaginationConfig = *paginationConfig(limit, offset)
+		res, err := s.ListVulnerablePackages(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.VulnerablePackageData]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.VulnerablePackageData]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.VulnerablePackageData]{
+			Items: res.VulnerablePackagesResults.GetItems(),
+			Total: res.VulnerablePackagesResults.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListNamedNetworksPager returns a Pager over the named networks matching request. The limit
+// and offset of request.SearchInput are set by the Pager.
+func (s *Namednetworks) ListNamedNetworksPager(request operations.ListNamedNetworksRequest, pageSize int64, opts ...operations.Option) *Pager[shared.NamednetworkNamedNetwork] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.NamednetworkNamedNetwork], error) {
+		request.SearchInput = searchPage(request.SearchInput, limit, offset)
+		res, err := s.ListNamedNetworks(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.NamednetworkNamedNetwork]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.NamednetworkNamedNetwork]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.NamednetworkNamedNetwork]{
+			Items: res.NamedNetworks.GetItems(),
+			Total: res.NamedNetworks.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListNNHitsPager returns a Pager over the named network hits matching request. The limit and
+// offset of request are set by the Pager.
+func (s *Namednetworks) ListNNHitsPager(request shared.SearchInput, pageSize int64, opts ...operations.Option) *Pager[shared.NamedNetworkHit] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.NamedNetworkHit], error) {
+		res, err := s.ListNNHits(ctx, searchPage(request, limit, offset), opts...)
+		if err != nil {
+			return Page[shared.NamedNetworkHit]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.NamedNetworkHit]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.NamedNetworkHit]{
+			Items: res.NamedNetworkHits.GetItems(),
+			Total: res.NamedNetworkHits.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListTagBasedPoliciesPager returns a Pager over the tag based policies matching request. The
+// limit and offset of request.SearchInput are set by the Pager.
+func (s *Tagbasedpolicies) ListTagBasedPoliciesPager(request operations.ListTagBasedPoliciesRequest, pageSize int64, opts ...operations.Option) *Pager[shared.TagBasedPolicySummary] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.TagBasedPolicySummary], error) {
+		request.SearchInput = searchPage(request.SearchInput, limit, offset)
+		res, err := s.ListTagBasedPolicies(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.TagBasedPolicySummary]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.TagBasedPolicySummary]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.TagBasedPolicySummary]{
+			Items: res.TagBasedPolicies.GetItems(),
+			Total: res.TagBasedPolicies.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListTagRulesPager returns a Pager over the tag rules matching request. The limit and offset
+// of request.SearchInput are set by the Pager.
+func (s *Tagrules) ListTagRulesPager(request operations.ListTagRulesRequest, pageSize int64, opts ...operations.Option) *Pager[shared.TagRule] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.TagRule], error) {
+		request.SearchInput = searchPage(request.SearchInput, limit, offset)
+		res, err := s.ListTagRules(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.TagRule]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.TagRule]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.TagRule]{
+			Items: res.TagRules.GetItems(),
+			Total: res.TagRules.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListTagsPager returns a Pager over the tags matching request. The limit and offset of
+// request are set by the Pager.
+func (s *Tags) ListTagsPager(request operations.ListTagsRequest, pageSize int64, opts ...operations.Option) *Pager[shared.Tag] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.Tag], error) {
+		request.Limit = Int64(limit)
+		request.Offset = Int64(offset)
+		res, err := s.ListTags(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.Tag]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.Tag]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.Tag]{
+			Items: res.Tags.GetItems(),
+			Total: res.Tags.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListAssetTagsPager returns a Pager over the tags of an asset matching request. The limit and
+// offset of request are set by the Pager.
+func (s *Tags) ListAssetTagsPager(request operations.ListAssetTagsRequest, pageSize int64, opts ...operations.Option) *Pager[shared.Tag] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.Tag], error) {
+		request.Limit = Int64(limit)
+		request.Offset = Int64(offset)
+		res, err := s.ListAssetTags(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.Tag]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.Tag]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.Tag]{
+			Items: res.Tags.GetItems(),
+			Total: res.Tags.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListTemplatesPager returns a Pager over the templates matching request. The limit and
+// offset of request.SearchInput are set by the Pager.
+func (s *Templates) ListTemplatesPager(request operations.ListTemplatesRequest, pageSize int64, opts ...operations.Option) *Pager[shared.TemplateSummary] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.TemplateSummary], error) {
+		request.SearchInput = searchPage(request.SearchInput, limit, offset)
+		res, err := s.ListTemplates(ctx, request, opts...)
+		if err != nil {
+			return Page[shared.TemplateSummary]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.TemplateSummary]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.TemplateSummary]{
+			Items: res.Templates.GetItems(),
+			Total: res.Templates.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
+
+// ListUsrGrpHitsPager returns a Pager over the user group hits matching request. The limit
+// and offset of request are set by the Pager.
+func (s *Usergroups) ListUsrGrpHitsPager(request shared.SearchInput, pageSize int64, opts ...operations.Option) *Pager[shared.UserGroupHit] {
+	return NewPager(pageSize, func(ctx context.Context, limit, offset int64) (Page[shared.UserGroupHit], error) {
+		res, err := s.ListUsrGrpHits(ctx, searchPage(request, limit, offset), opts...)
+		if err != nil {
+			return Page[shared.UserGroupHit]{}, err
+		}
+		if res.StatusCode != 200 {
+			return Page[shared.UserGroupHit]{}, unexpectedStatus(res.StatusCode)
+		}
+		return Page[shared.UserGroupHit]{
+			Items: res.UserGroupHits.GetItems(),
+			Total: res.UserGroupHits.GetMetadata().GetTotal(),
+		}, nil
+	})
+}
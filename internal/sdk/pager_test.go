@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// fakePages serves the integers below total as pages, recording the offsets requested. Pages
+// are size long when size is set, whatever the limit, as an API ignoring it would answer.
+type fakePages struct {
+	total     int64
+	size      int64
+	withTotal bool
+	failAt    int64
+	offsets   []int64
+}
+
+func (f *fakePages) fetch(ctx context.Context, limit, offset int64) (Page[int64], error) {
+	f.offsets = append(f.offsets, offset)
+	if f.failAt > 0 && offset >= f.failAt {
+		return Page[int64]{}, errors.New("page failed")
+	}
+	if f.size > 0 {
+		limit = f.size
+	}
+
+	var page Page[int64]
+	for i := offset; i < min(offset+limit, f.total); i++ {
+		page.Items = append(page.Items, i)
+	}
+	if f.withTotal {
+		page.Total = Int64(f.total)
+	}
+	return page, nil
+}
+
+// upTo returns the integers below n
+func upTo(n int64) []int64 {
+	var items []int64
+	for i := int64(0); i < n; i++ {
+		items = append(items, i)
+	}
+	return items
+}
+
+func TestPagerCollect(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       fakePages
+		wantOffsets []int64
+	}{
+		{name: "short page stop", pages: fakePages{total: 25}, wantOffsets: []int64{0, 10, 20}},
+		{name: "empty last page", pages: fakePages{total: 20}, wantOffsets: []int64{0, 10, 20}},
+		{name: "total stop", pages: fakePages{total: 20, withTotal: true}, wantOffsets: []int64{0, 10}},
+		{name: "no results", pages: fakePages{total: 0, withTotal: true}, wantOffsets: []int64{0}},
+		{name: "limit ignored", pages: fakePages{total: 25, size: 25}, wantOffsets: []int64{0}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			items, err := NewPager(10, test.pages.fetch).Collect(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(items, upTo(test.pages.total)) {
+				t.Errorf("Collect() = %v, want %v", items, upTo(test.pages.total))
+			}
+			if !slices.Equal(test.pages.offsets, test.wantOffsets) {
+				t.Errorf("requested offsets %v, want %v", test.pages.offsets, test.wantOffsets)
+			}
+		})
+	}
+}
+
+func TestPagerAllEarlyBreak(t *testing.T) {
+	pages := fakePages{total: 100}
+	var items []int64
+	for item, err := range NewPager(10, pages.fetch).All(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item == 12 {
+			break
+		}
+		items = append(items, item)
+	}
+	if !slices.Equal(items, upTo(12)) {
+		t.Errorf("iterated over %v, want %v", items, upTo(12))
+	}
+	if !slices.Equal(pages.offsets, []int64{0, 10}) {
+		t.Errorf("requested offsets %v, want only the pages reached", pages.offsets)
+	}
+}
+
+func TestPagerAllErrorMidStream(t *testing.T) {
+	pages := fakePages{total: 100, failAt: 20}
+	var items []int64
+	var errs []error
+	for item, err := range NewPager(10, pages.fetch).All(context.Background()) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		items = append(items, item)
+	}
+	if !slices.Equal(items, upTo(20)) {
+		t.Errorf("iterated over %v, want the %d items before the failure", items, 20)
+	}
+	if len(errs) != 1 || errs[0].Error() != "page failed" {
+		t.Errorf("got errors %v, want the page error once", errs)
+	}
+
+	if _, err := NewPager(10, (&fakePages{total: 100, failAt: 20}).fetch).Collect(context.Background()); err == nil {
+		t.Error("Collect() succeeded past a failed page")
+	}
+}
+
+func TestPagerAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pages := fakePages{total: 100}
+	_, err := NewPager(10, pages.fetch).Collect(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want the context error", err)
+	}
+	if len(pages.offsets) != 0 {
+		t.Errorf("requested offsets %v with a cancelled context", pages.offsets)
+	}
+}
+
+func TestPagerFind(t *testing.T) {
+	pages := fakePages{total: 100}
+	item, ok, err := NewPager(10, pages.fetch).Find(context.Background(), func(i int64) bool { return i == 15 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || item != 15 {
+		t.Errorf("Find() = %d, %v, want 15", item, ok)
+	}
+	if !slices.Equal(pages.offsets, []int64{0, 10}) {
+		t.Errorf("requested offsets %v, want none past the match", pages.offsets)
+	}
+
+	_, ok, err = NewPager(10, (&fakePages{total: 5}).fetch).Find(context.Background(), func(i int64) bool { return i == 15 })
+	if err != nil || ok {
+		t.Errorf("Find() found %v, err %v, want nothing", ok, err)
+	}
+}
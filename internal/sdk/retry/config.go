@@ -112,7 +112,8 @@ func retryIntervalFromResponse(res *http.Response) time.Duration {
 		}
 	}
 
-	parsedDate, err := time.Parse(time.RFC1123, retryVal)
+	// HTTP dates may use any of the formats of RFC 9110, section 5.6.7
+	parsedDate, err := http.ParseTime(retryVal)
 	if err == nil {
 		delta := parsedDate.Sub(time.Now())
 		if delta < 0 {
@@ -0,0 +1,42 @@
+package retry
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryIntervalFromResponse(t *testing.T) {
+	inOneMinute := time.Now().Add(time.Minute).UTC()
+	tests := []struct {
+		name       string
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{name: "none", retryAfter: "", min: 0, max: 0},
+		{name: "seconds", retryAfter: "7", min: 7 * time.Second, max: 7 * time.Second},
+		{name: "negative seconds", retryAfter: "-3", min: 0, max: 0},
+		{name: "IMF-fixdate", retryAfter: inOneMinute.Format(http.TimeFormat), min: 58 * time.Second, max: time.Minute},
+		{name: "RFC 850 date", retryAfter: inOneMinute.Format(time.RFC850), min: 58 * time.Second, max: time.Minute},
+		{name: "ANSI C date", retryAfter: inOneMinute.Format(time.ANSIC), min: 58 * time.Second, max: time.Minute},
+		{name: "past date", retryAfter: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), min: 0, max: 0},
+		{name: "garbage", retryAfter: "soon", min: 0, max: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if test.retryAfter != "" {
+				res.Header.Set("Retry-After", test.retryAfter)
+			}
+
+			var temporary *TemporaryError
+			if !errors.As(TemporaryFromResponse("request failed", res), &temporary) {
+				t.Fatal("TemporaryFromResponse did not return a *TemporaryError")
+			}
+			if got := temporary.RetryAfter(); got < test.min || got > test.max {
+				t.Errorf("RetryAfter() = %s, want between %s and %s", got, test.min, test.max)
+			}
+		})
+	}
+}
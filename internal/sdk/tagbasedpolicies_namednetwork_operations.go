@@ -1,14 +1,13 @@
 package sdk
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/internal/hooks"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/internal/utils"
+	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/errors"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/operations"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/models/shared"
 	"github.com/colortokens/terraform-provider-xshield/internal/sdk/retry"
@@ -124,6 +123,12 @@ func (s *Tagbasedpolicies) TagBasedPolicyBulkNamedNetworkApply(ctx context.Conte
 		resp, err := client.Do(req)
 		return resp, err
 	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %w", err)
+	}
+	if httpRes == nil {
+		return nil, fmt.Errorf("error sending request: no response")
+	}
 
 	contentType := httpRes.Header.Get("Content-Type")
 
@@ -134,15 +139,7 @@ func (s *Tagbasedpolicies) TagBasedPolicyBulkNamedNetworkApply(ctx context.Conte
 	}
 
 	if httpRes.StatusCode >= 400 {
-		// Try to read the response body for more details
-		if httpRes.Body != nil {
-			bodyBytes, readErr := io.ReadAll(httpRes.Body)
-			if readErr == nil {
-				// Reset the body for further processing
-				httpRes.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-			}
-		}
-		return nil, fmt.Errorf("unexpected response from API. Got an unexpected response code %v", httpRes.StatusCode)
+		return nil, errors.NewAPIError(httpRes)
 	}
 
 	return res, nil
@@ -261,15 +258,7 @@ func (s *Tagbasedpolicies) TagBasedPolicyBulkNamedNetworkUnApply(ctx context.Con
 	// No need to read the body here for 202 responses
 
 	if httpRes.StatusCode >= 400 {
-		// Try to read the response body for more details
-		if httpRes.Body != nil {
-			bodyBytes, readErr := io.ReadAll(httpRes.Body)
-			if readErr == nil {
-				// Reset the body for further processing
-				httpRes.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-			}
-		}
-		return nil, fmt.Errorf("unexpected response from API. Got an unexpected response code %v", httpRes.StatusCode)
+		return nil, errors.NewAPIError(httpRes)
 	}
 
 	return res, nil
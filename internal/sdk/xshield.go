@@ -59,6 +59,10 @@ func Float64(f float64) *float64 { return &f }
 // Pointer provides a helper function to return a pointer to a type
 func Pointer[T any](v T) *T { return &v }
 
+// OperationID returns the ID of the SDK operation a request was sent for, such as
+// "CreateTagRule", or an empty string for requests not sent by the SDK
+func OperationID(ctx context.Context) string { return hooks.OperationID(ctx) }
+
 type sdkConfiguration struct {
 	Client            HTTPClient
 	Security          func(context.Context) (interface{}, error)
@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = DurationValidator{}
+
+type DurationValidator struct{}
+
+func (validator DurationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as \"30m\", \"72h\" or \"720h\""
+}
+
+func (validator DurationValidator) MarkdownDescription(ctx context.Context) string {
+	return validator.Description(ctx)
+}
+
+func (validator DurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// Only validate the attribute configuration value if it is known.
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d <= 0 {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path,
+			validator.MarkdownDescription(ctx),
+			req.ConfigValue.ValueString(),
+		))
+		return
+	}
+}
+
+// IsDuration returns an AttributeValidator which ensures that any configured
+// attribute value:
+//
+//   - Is a String.
+//   - Can be parsed by time.ParseDuration and is greater than zero.
+//
+// Null (unconfigured) and unknown (known after apply) values are skipped.
+func IsDuration() validator.String {
+	return DurationValidator{}
+}